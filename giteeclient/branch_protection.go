@@ -0,0 +1,150 @@
+package giteeclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	// ProtectionAdmin stands for the administrators of the repository
+	// in Pushers and Mergers of BranchProtectionRule.
+	ProtectionAdmin = "admin"
+
+	// ProtectionNone forbids anyone to push or merge.
+	ProtectionNone = "none"
+)
+
+// BranchProtectionRule is a rule protecting the branches which match Wildcard.
+type BranchProtectionRule struct {
+	ID int32
+
+	// Wildcard is a branch name, or a pattern such as "release-*".
+	Wildcard string
+
+	// Pushers are who may push to the branches. The item is ProtectionAdmin,
+	// ProtectionNone or the login of a user.
+	Pushers []string
+
+	// Mergers are who may merge the pull requests into the branches.
+	// The items are the same as Pushers.
+	Mergers []string
+
+	// ReviewCount is the number of approvals which a pull request requires.
+	ReviewCount int32
+}
+
+// branchProtectionRule is the rule in the format of Gitee,
+// which joins the pushers and mergers with ";".
+type branchProtectionRule struct {
+	ID          int32  `json:"id,omitempty"`
+	Wildcard    string `json:"wildcard"`
+	Pusher      string `json:"pusher"`
+	Merger      string `json:"merger"`
+	ReviewCount int32  `json:"review_count"`
+}
+
+func (r BranchProtectionRule) toGitee() branchProtectionRule {
+	return branchProtectionRule{
+		Wildcard:    r.Wildcard,
+		Pusher:      strings.Join(r.Pushers, ";"),
+		Merger:      strings.Join(r.Mergers, ";"),
+		ReviewCount: r.ReviewCount,
+	}
+}
+
+func (r branchProtectionRule) toRule() BranchProtectionRule {
+	split := func(s string) []string {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(s, ";")
+	}
+
+	return BranchProtectionRule{
+		ID:          r.ID,
+		Wildcard:    r.Wildcard,
+		Pushers:     split(r.Pusher),
+		Mergers:     split(r.Merger),
+		ReviewCount: r.ReviewCount,
+	}
+}
+
+func branchSettingPath(org, repo, wildcard string) string {
+	return apiPath("repos", org, repo, "branches", wildcard, "setting")
+}
+
+func (c *client) ListBranchProtectionRules(ctx context.Context, org, repo string) ([]BranchProtectionRule, error) {
+	ctx = withMethod(ctx, "ListBranchProtectionRules")
+
+	var rules []branchProtectionRule
+	err := c.request(
+		ctx, http.MethodGet, apiPath("repos", org, repo, "branches", "setting"),
+		nil, &rules, "list branch protection rules",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]BranchProtectionRule, len(rules))
+	for i := range rules {
+		r[i] = rules[i].toRule()
+	}
+	return r, nil
+}
+
+// GetBranchProtectionRule returns the rule whose wildcard is exactly the one
+// specified. It doesn't match the branch name against the wildcards.
+func (c *client) GetBranchProtectionRule(ctx context.Context, org, repo, wildcard string) (BranchProtectionRule, error) {
+	ctx = withMethod(ctx, "GetBranchProtectionRule")
+
+	rules, err := c.ListBranchProtectionRules(ctx, org, repo)
+	if err != nil {
+		return BranchProtectionRule{}, err
+	}
+
+	for i := range rules {
+		if rules[i].Wildcard == wildcard {
+			return rules[i], nil
+		}
+	}
+
+	return BranchProtectionRule{}, &Error{
+		Op:         "get branch protection rule",
+		StatusCode: http.StatusNotFound,
+		err:        errors.New("rule does not exist"),
+	}
+}
+
+func (c *client) CreateBranchProtectionRule(ctx context.Context, org, repo string, rule BranchProtectionRule) (BranchProtectionRule, error) {
+	ctx = withMethod(ctx, "CreateBranchProtectionRule")
+
+	var v branchProtectionRule
+	err := c.request(
+		ctx, http.MethodPut, apiPath("repos", org, repo, "branches", "setting", "new"),
+		rule.toGitee(), &v, "create branch protection rule",
+	)
+	return v.toRule(), err
+}
+
+// UpdateBranchProtectionRule replaces the rule of wildcard with rule, and
+// rule.Wildcard can be different to rename it.
+func (c *client) UpdateBranchProtectionRule(ctx context.Context, org, repo, wildcard string, rule BranchProtectionRule) (BranchProtectionRule, error) {
+	ctx = withMethod(ctx, "UpdateBranchProtectionRule")
+
+	var v branchProtectionRule
+	err := c.request(
+		ctx, http.MethodPut, branchSettingPath(org, repo, wildcard),
+		rule.toGitee(), &v, "update branch protection rule",
+	)
+	return v.toRule(), err
+}
+
+func (c *client) DeleteBranchProtectionRule(ctx context.Context, org, repo, wildcard string) error {
+	ctx = withMethod(ctx, "DeleteBranchProtectionRule")
+	return c.request(
+		ctx, http.MethodDelete, branchSettingPath(org, repo, wildcard),
+		nil, nil, "delete branch protection rule",
+	)
+}
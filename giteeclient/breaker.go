@@ -0,0 +1,284 @@
+package giteeclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerOpenTimeout      = 30 * time.Second
+)
+
+// BreakerState is the state of circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets all the requests pass.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen rejects all the requests.
+	BreakerOpen
+
+	// BreakerHalfOpen lets a few requests pass to probe whether Gitee recovers.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitOpenError is returned without sending the request while the breaker is open.
+type CircuitOpenError struct {
+	// RetryAt is when the breaker will let a request pass to probe.
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open, gitee is unavailable until %s", e.RetryAt.Format(time.RFC3339))
+}
+
+// IsCircuitOpen returns true if the request is rejected by the circuit breaker.
+func IsCircuitOpen(err error) bool {
+	var e *CircuitOpenError
+	return errors.As(err, &e)
+}
+
+// BreakerOptions holds the options to create a Breaker.
+type BreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures to open the breaker.
+	// DefaultBreakerFailureThreshold will be used if it is zero.
+	FailureThreshold int
+
+	// SuccessThreshold is the number of consecutive successes in half-open
+	// state to close the breaker. 1 will be used if it is zero.
+	SuccessThreshold int
+
+	// OpenTimeout is how long the breaker stays open before it turns half-open.
+	// DefaultBreakerOpenTimeout will be used if it is zero.
+	OpenTimeout time.Duration
+
+	// HalfOpenRequests is the maximum number of requests in flight in half-open
+	// state, and the others are rejected. 1 will be used if it is zero.
+	HalfOpenRequests int
+}
+
+// Breaker is a circuit breaker which stops sending the requests when Gitee
+// is unavailable. A request fails if it gets a network error or a server error.
+// The breaker opens after FailureThreshold consecutive failures, and rejects
+// the requests with CircuitOpenError until OpenTimeout passes. Then it turns
+// half-open and lets HalfOpenRequests requests pass. It closes after
+// SuccessThreshold consecutive successes, and opens again on any failure.
+//
+// It can be shared by several clients. It implements prometheus.Collector
+// to report the state, state changes and rejected requests.
+type Breaker struct {
+	opts BreakerOptions
+
+	mut       sync.Mutex
+	state     BreakerState
+	failures  int
+	successes int
+	probing   int
+	openedAt  time.Time
+
+	stateGauge prometheus.Gauge
+	changes    *prometheus.CounterVec
+	rejected   prometheus.Counter
+}
+
+// NewBreaker creates a Breaker which should be set to ClientOptions.Breaker.
+func NewBreaker(opts BreakerOptions) *Breaker {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if opts.SuccessThreshold <= 0 {
+		opts.SuccessThreshold = 1
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+	if opts.HalfOpenRequests <= 0 {
+		opts.HalfOpenRequests = 1
+	}
+
+	return &Breaker{
+		opts: opts,
+		stateGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "breaker_state",
+			Help:      "The state of circuit breaker, 0 is closed, 1 is open and 2 is half-open.",
+		}),
+		changes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "breaker_state_changes_total",
+				Help:      "The number of state changes of circuit breaker.",
+			},
+			[]string{"from", "to"},
+		),
+		rejected: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "breaker_rejected_requests_total",
+			Help:      "The number of requests rejected by circuit breaker.",
+		}),
+	}
+}
+
+// State returns the current state.
+func (b *Breaker) State() BreakerState {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	return b.state
+}
+
+// Describe implements prometheus.Collector.
+func (b *Breaker) Describe(ch chan<- *prometheus.Desc) {
+	b.stateGauge.Describe(ch)
+	b.changes.Describe(ch)
+	b.rejected.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (b *Breaker) Collect(ch chan<- prometheus.Metric) {
+	b.stateGauge.Collect(ch)
+	b.changes.Collect(ch)
+	b.rejected.Collect(ch)
+}
+
+// allow reports whether the request can be sent, and whether it is a probe
+// in half-open state.
+func (b *Breaker) allow() (bool, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if b.state == BreakerOpen {
+		retryAt := b.openedAt.Add(b.opts.OpenTimeout)
+		if time.Now().Before(retryAt) {
+			b.rejected.Inc()
+			return false, &CircuitOpenError{RetryAt: retryAt}
+		}
+
+		b.setState(BreakerHalfOpen)
+	}
+
+	if b.state == BreakerHalfOpen {
+		if b.probing >= b.opts.HalfOpenRequests {
+			b.rejected.Inc()
+			return false, &CircuitOpenError{RetryAt: time.Now()}
+		}
+
+		b.probing++
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// done records the result of request. The result is ignored if the caller
+// canceled the request, since it says nothing about Gitee.
+func (b *Breaker) done(probe, failed, ignored bool) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+
+	if probe {
+		b.probing--
+	}
+
+	if ignored {
+		return
+	}
+
+	switch b.state {
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+
+		if b.failures++; b.failures >= b.opts.FailureThreshold {
+			b.setState(BreakerOpen)
+		}
+
+	case BreakerHalfOpen:
+		// The requests sent before the breaker turned half-open are not probes.
+		if !probe {
+			return
+		}
+
+		if failed {
+			b.setState(BreakerOpen)
+			return
+		}
+
+		if b.successes++; b.successes >= b.opts.SuccessThreshold {
+			b.setState(BreakerClosed)
+		}
+	}
+}
+
+func (b *Breaker) setState(s BreakerState) {
+	from := b.state
+
+	b.state = s
+	b.failures = 0
+	b.successes = 0
+	if s == BreakerOpen {
+		b.openedAt = time.Now()
+	}
+
+	b.stateGauge.Set(float64(s))
+	b.changes.WithLabelValues(from.String(), s.String()).Inc()
+
+	l := logrus.WithFields(logrus.Fields{"from": from.String(), "to": s.String()})
+	if s == BreakerOpen {
+		l.Warn("the circuit breaker of gitee client opens")
+	} else {
+		l.Info("the circuit breaker of gitee client changes state")
+	}
+}
+
+func isServerFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	code := resp.StatusCode
+	return code >= http.StatusInternalServerError && code != http.StatusNotImplemented
+}
+
+// breakerTransport sends the request only if the breaker allows it.
+type breakerTransport struct {
+	breaker *Breaker
+	base    http.RoundTripper
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	probe, err := t.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+
+	canceled := errors.Is(req.Context().Err(), context.Canceled)
+	t.breaker.done(probe, isServerFailure(resp, err), canceled)
+
+	return resp, err
+}
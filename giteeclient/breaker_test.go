@@ -0,0 +1,113 @@
+package giteeclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestBreaker(t *testing.T) {
+	var calls, healthy int32
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"message":"Bad Gateway"}`))
+			return
+		}
+		w.Write([]byte(`{"login":"bot"}`))
+	}))
+	defer s.Close()
+
+	b := NewBreaker(BreakerOptions{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond})
+	c := NewContextClient(
+		func() []byte { return []byte("token") },
+		ClientOptions{BaseURL: s.URL + "/api", Breaker: b},
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetBot(ctx); StatusCode(err) != http.StatusBadGateway {
+			t.Fatalf("expected 502, got %v", err)
+		}
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected the breaker is open, got %s", b.State())
+	}
+
+	_, err := c.GetBot(ctx)
+	if !IsCircuitOpen(err) {
+		t.Fatalf("expected the circuit open error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 2 {
+		t.Errorf("expected the request is not sent, got %d calls", n)
+	}
+
+	// The probe fails, so the breaker opens again.
+	time.Sleep(60 * time.Millisecond)
+	if _, err := c.GetBot(ctx); StatusCode(err) != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %v", err)
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected the breaker opens again, got %s", b.State())
+	}
+
+	atomic.StoreInt32(&healthy, 1)
+	time.Sleep(60 * time.Millisecond)
+	if _, err := c.GetBot(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("expected the breaker is closed, got %s", b.State())
+	}
+
+	testCases := []struct {
+		from     BreakerState
+		to       BreakerState
+		expected float64
+	}{
+		{from: BreakerClosed, to: BreakerOpen, expected: 1},
+		{from: BreakerOpen, to: BreakerHalfOpen, expected: 2},
+		{from: BreakerHalfOpen, to: BreakerOpen, expected: 1},
+		{from: BreakerHalfOpen, to: BreakerClosed, expected: 1},
+	}
+	for _, tc := range testCases {
+		v := testutil.ToFloat64(b.changes.WithLabelValues(tc.from.String(), tc.to.String()))
+		if v != tc.expected {
+			t.Errorf("%s -> %s: expected %v changes, got %v", tc.from, tc.to, tc.expected, v)
+		}
+	}
+	if v := testutil.ToFloat64(b.rejected); v != 1 {
+		t.Errorf("expected 1 rejected request, got %v", v)
+	}
+}
+
+func TestBreakerHalfOpenLimitsProbes(t *testing.T) {
+	b := NewBreaker(BreakerOptions{FailureThreshold: 1, OpenTimeout: time.Millisecond})
+
+	b.mut.Lock()
+	b.setState(BreakerOpen)
+	b.mut.Unlock()
+	time.Sleep(2 * time.Millisecond)
+
+	probe, err := b.allow()
+	if err != nil || !probe {
+		t.Fatalf("expected a probe, got %v, %v", probe, err)
+	}
+	if _, err := b.allow(); !IsCircuitOpen(err) {
+		t.Errorf("expected the second request is rejected, got %v", err)
+	}
+
+	// A canceled request says nothing about Gitee.
+	b.done(probe, true, true)
+	if b.State() != BreakerHalfOpen {
+		t.Errorf("expected the breaker is still half-open, got %s", b.State())
+	}
+}
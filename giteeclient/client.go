@@ -3,35 +3,46 @@ package giteeclient
 import (
 	"context"
 	"encoding/base64"
-	"fmt"
+	"errors"
+	"net/http"
 	"strings"
+	"time"
 
 	sdk "gitee.com/openeuler/go-gitee/gitee"
 	"github.com/antihax/optional"
-	"golang.org/x/oauth2"
 )
 
-var _ Client = (*client)(nil)
+var _ ContextClient = (*client)(nil)
 
 type client struct {
-	ac *sdk.APIClient
+	ac      *sdk.APIClient
+	conf    *sdk.Configuration
+	perPage int32
+	users   *userCache
 }
 
+// NewClient creates a client with the default retry policy.
+// It fetches the token by getToken on every request.
 func NewClient(getToken func() []byte) Client {
-	token := string(getToken())
-
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
+	return NewClientWithOptions(getToken, ClientOptions{RetryPolicy: DefaultRetryPolicy()})
+}
 
-	conf := sdk.NewConfiguration()
-	conf.HTTPClient = oauth2.NewClient(context.Background(), ts)
+// NewContextClient creates a client whose methods accept a context.Context.
+func NewContextClient(getToken func() []byte, opts ClientOptions) ContextClient {
+	return newClient(opts.newConfiguration(getToken), opts)
+}
 
-	c := sdk.NewAPIClient(conf)
-	return &client{ac: c}
+func newClient(conf *sdk.Configuration, opts ClientOptions) *client {
+	return &client{
+		ac:      sdk.NewAPIClient(conf),
+		conf:    conf,
+		perPage: normalizePerPage(opts.PerPage),
+		users:   newUserCache(opts.UserCacheTTL),
+	}
 }
 
-func (c *client) CreatePullRequest(org, repo, title, body, head, base string, canModify bool) (sdk.PullRequest, error) {
+func (c *client) CreatePullRequest(ctx context.Context, org, repo, title, body, head, base string, canModify bool) (sdk.PullRequest, error) {
+	ctx = withMethod(ctx, "CreatePullRequest")
 	opts := sdk.CreatePullRequestParam{
 		Title:             title,
 		Head:              head,
@@ -40,19 +51,14 @@ func (c *client) CreatePullRequest(org, repo, title, body, head, base string, ca
 		PruneSourceBranch: true,
 	}
 
-	pr, _, err := c.ac.PullRequestsApi.PostV5ReposOwnerRepoPulls(
-		context.Background(), org, repo, opts)
+	pr, r, err := c.ac.PullRequestsApi.PostV5ReposOwnerRepoPulls(
+		ctx, org, repo, opts)
 
-	return pr, formatErr(err, "create pull request")
+	return pr, formatErr(err, r, "create pull request")
 }
 
-func (c *client) GetPullRequests(org, repo string, opts ListPullRequestOpt) ([]sdk.PullRequest, error) {
-
-	setStr := func(t *optional.String, v string) {
-		if v != "" {
-			*t = optional.NewString(v)
-		}
-	}
+func (c *client) GetPullRequests(ctx context.Context, org, repo string, opts ListPullRequestOpt) ([]sdk.PullRequest, error) {
+	ctx = withMethod(ctx, "GetPullRequests")
 
 	opt := sdk.GetV5ReposOwnerRepoPullsOpts{}
 	setStr(&opt.State, opts.State)
@@ -68,482 +74,650 @@ func (c *client) GetPullRequests(org, repo string, opts ListPullRequestOpt) ([]s
 	}
 
 	var r []sdk.PullRequest
-	p := int32(1)
-	for {
-		opt.Page = optional.NewInt32(p)
-		prs, _, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPulls(context.Background(), org, repo, &opt)
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		prs, resp, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPulls(ctx, org, repo, &opt)
 		if err != nil {
-			return nil, formatErr(err, "get pull requests")
-		}
-
-		if len(prs) == 0 {
-			break
+			return 0, formatErr(err, resp, "get pull requests")
 		}
 
 		r = append(r, prs...)
-		p++
+		return len(prs), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return r, nil
 }
 
-func (c *client) UpdatePullRequest(org, repo string, number int32, param sdk.PullRequestUpdateParam) (sdk.PullRequest, error) {
-	pr, _, err := c.ac.PullRequestsApi.PatchV5ReposOwnerRepoPullsNumber(context.Background(), org, repo, number, param)
-	return pr, formatErr(err, "update pull request")
+func setStr(t *optional.String, v string) {
+	if v != "" {
+		*t = optional.NewString(v)
+	}
 }
 
-func (c *client) GetGiteePullRequest(org, repo string, number int32) (sdk.PullRequest, error) {
-	pr, _, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumber(
-		context.Background(), org, repo, number, nil)
-	return pr, formatErr(err, "get pull request")
+func (c *client) UpdatePullRequest(ctx context.Context, org, repo string, number int32, param sdk.PullRequestUpdateParam) (sdk.PullRequest, error) {
+	ctx = withMethod(ctx, "UpdatePullRequest")
+	pr, r, err := c.ac.PullRequestsApi.PatchV5ReposOwnerRepoPullsNumber(ctx, org, repo, number, param)
+	return pr, formatErr(err, r, "update pull request")
 }
 
-func (c *client) GetBot() (sdk.User, error) {
-	u, _, err := c.ac.UsersApi.GetV5User(context.Background(), nil)
+func (c *client) GetGiteePullRequest(ctx context.Context, org, repo string, number int32) (sdk.PullRequest, error) {
+	ctx = withMethod(ctx, "GetGiteePullRequest")
+	pr, r, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumber(
+		ctx, org, repo, number, nil)
+	return pr, formatErr(err, r, "get pull request")
+}
+
+func (c *client) GetBot(ctx context.Context) (sdk.User, error) {
+	ctx = withMethod(ctx, "GetBot")
+	u, r, err := c.ac.UsersApi.GetV5User(ctx, nil)
 	if err != nil {
-		return u, formatErr(err, "fetch bot name")
+		return u, formatErr(err, r, "fetch bot name")
 	}
 	return u, nil
 }
 
-func (c *client) ListCollaborators(org, repo string) ([]sdk.ProjectMember, error) {
+func (c *client) ListCollaborators(ctx context.Context, org, repo string) ([]sdk.ProjectMember, error) {
+	ctx = withMethod(ctx, "ListCollaborators")
 	var r []sdk.ProjectMember
 
+	err := c.IterateCollaborators(ctx, org, repo, func(v sdk.ProjectMember) error {
+		r = append(r, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (c *client) IterateCollaborators(ctx context.Context, org, repo string, handle func(sdk.ProjectMember) error) error {
+	ctx = withMethod(ctx, "IterateCollaborators")
 	opt := sdk.GetV5ReposOwnerRepoCollaboratorsOpts{}
-	p := int32(1)
-	for {
-		opt.Page = optional.NewInt32(p)
-		cs, _, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoCollaborators(context.Background(), org, repo, &opt)
+
+	return Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		cs, resp, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoCollaborators(ctx, org, repo, &opt)
 		if err != nil {
-			return nil, formatErr(err, "list collaborators")
-		}
-		if len(cs) == 0 {
-			break
+			return 0, formatErr(err, resp, "list collaborators")
 		}
 
-		r = append(r, cs...)
-		p++
-	}
-	return r, nil
+		for i := range cs {
+			if err := handle(cs[i]); err != nil {
+				return 0, err
+			}
+		}
+		return len(cs), nil
+	})
 }
 
-func (c *client) GetRef(org, repo, ref string) (string, error) {
+func (c *client) GetRef(ctx context.Context, org, repo, ref string) (string, error) {
+	ctx = withMethod(ctx, "GetRef")
 	branch := strings.TrimPrefix(ref, "heads/")
-	b, _, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoBranchesBranch(context.Background(), org, repo, branch, nil)
+	b, r, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoBranchesBranch(ctx, org, repo, branch, nil)
 	if err != nil {
-		return "", formatErr(err, "get branch")
+		return "", formatErr(err, r, "get branch")
 	}
 
 	return b.Commit.Sha, nil
 }
 
-func (c *client) GetPullRequestChanges(org, repo string, number int32) ([]sdk.PullRequestFiles, error) {
-	fs, _, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberFiles(
-		context.Background(), org, repo, number, nil)
+func (c *client) GetPullRequestChanges(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestFiles, error) {
+	ctx = withMethod(ctx, "GetPullRequestChanges")
+	fs, r, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberFiles(
+		ctx, org, repo, number, nil)
 	if err != nil {
-		return nil, formatErr(err, "list files of pr")
+		return nil, formatErr(err, r, "list files of pr")
 	}
 
 	return fs, nil
 }
 
-func (c *client) GetPRLabels(org, repo string, number int32) ([]sdk.Label, error) {
+func (c *client) GetPRLabels(ctx context.Context, org, repo string, number int32) ([]sdk.Label, error) {
+	ctx = withMethod(ctx, "GetPRLabels")
 	var r []sdk.Label
 
-	p := int32(1)
+	err := c.IteratePRLabels(ctx, org, repo, number, func(v sdk.Label) error {
+		r = append(r, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *client) IteratePRLabels(ctx context.Context, org, repo string, number int32, handle func(sdk.Label) error) error {
+	ctx = withMethod(ctx, "IteratePRLabels")
 	opt := sdk.GetV5ReposOwnerRepoPullsNumberLabelsOpts{}
-	for {
-		opt.Page = optional.NewInt32(p)
-		ls, _, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberLabels(
-			context.Background(), org, repo, number, &opt)
+
+	return Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		ls, resp, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberLabels(
+			ctx, org, repo, number, &opt)
 		if err != nil {
-			return nil, formatErr(err, "list labels of pr")
+			return 0, formatErr(err, resp, "list labels of pr")
 		}
 
-		if len(ls) == 0 {
-			break
+		for i := range ls {
+			if err := handle(ls[i]); err != nil {
+				return 0, err
+			}
 		}
+		return len(ls), nil
+	})
+}
 
-		r = append(r, ls...)
-		p++
+func (c *client) ListPRComments(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestComments, error) {
+	ctx = withMethod(ctx, "ListPRComments")
+	var r []sdk.PullRequestComments
+
+	err := c.IteratePRComments(ctx, org, repo, number, func(v sdk.PullRequestComments) error {
+		r = append(r, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return r, nil
 }
 
-func (c *client) ListPRComments(org, repo string, number int32) ([]sdk.PullRequestComments, error) {
-	var r []sdk.PullRequestComments
-
-	p := int32(1)
+func (c *client) IteratePRComments(ctx context.Context, org, repo string, number int32, handle func(sdk.PullRequestComments) error) error {
+	ctx = withMethod(ctx, "IteratePRComments")
 	opt := sdk.GetV5ReposOwnerRepoPullsNumberCommentsOpts{}
-	for {
-		opt.Page = optional.NewInt32(p)
-		cs, _, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberComments(
-			context.Background(), org, repo, number, &opt)
+
+	return Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		cs, resp, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberComments(
+			ctx, org, repo, number, &opt)
 		if err != nil {
-			return nil, formatErr(err, "list comments of pr")
+			return 0, formatErr(err, resp, "list comments of pr")
 		}
 
-		if len(cs) == 0 {
-			break
+		for i := range cs {
+			if err := handle(cs[i]); err != nil {
+				return 0, err
+			}
 		}
-
-		r = append(r, cs...)
-		p++
-	}
-
-	return r, nil
+		return len(cs), nil
+	})
 }
 
-func (c *client) ListPROperationLogs(org, repo string, number int32) ([]sdk.OperateLog, error) {
-	v, _, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberOperateLogs(
-		context.Background(), org, repo, number, nil)
+func (c *client) ListPROperationLogs(ctx context.Context, org, repo string, number int32) ([]sdk.OperateLog, error) {
+	ctx = withMethod(ctx, "ListPROperationLogs")
+	v, r, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberOperateLogs(
+		ctx, org, repo, number, nil)
 	if err != nil {
-		return nil, formatErr(err, "list operation logs of pr")
+		return nil, formatErr(err, r, "list operation logs of pr")
 	}
 	return v, nil
 }
 
-func (c *client) ListPrIssues(org, repo string, number int32) ([]sdk.Issue, error) {
+func (c *client) ListPrIssues(ctx context.Context, org, repo string, number int32) ([]sdk.Issue, error) {
+	ctx = withMethod(ctx, "ListPrIssues")
 	var issues []sdk.Issue
-	p := int32(1)
 	opt := sdk.GetV5ReposOwnerRepoPullsNumberIssuesOpts{}
-	for {
-		opt.Page = optional.NewInt32(p)
-		iss, _, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberIssues(context.Background(), org, repo, number, &opt)
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		iss, r, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberIssues(ctx, org, repo, number, &opt)
 		if err != nil {
-			return nil, formatErr(err, "list issues of pr")
-		}
-		if len(iss) == 0 {
-			break
+			return 0, formatErr(err, r, "list issues of pr")
 		}
 		issues = append(issues, iss...)
-		p++
+		return len(iss), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return issues, nil
 }
 
-func (c *client) DeletePRComment(org, repo string, ID int32) error {
-	_, err := c.ac.PullRequestsApi.DeleteV5ReposOwnerRepoPullsCommentsId(
-		context.Background(), org, repo, ID, nil)
-	return formatErr(err, "delete comment of pr")
+func (c *client) DeletePRComment(ctx context.Context, org, repo string, ID int32) error {
+	ctx = withMethod(ctx, "DeletePRComment")
+	r, err := c.ac.PullRequestsApi.DeleteV5ReposOwnerRepoPullsCommentsId(
+		ctx, org, repo, ID, nil)
+	return formatErr(err, r, "delete comment of pr")
 }
 
-func (c *client) CreatePRComment(org, repo string, number int32, comment string) error {
+func (c *client) CreatePRComment(ctx context.Context, org, repo string, number int32, comment string) error {
+	ctx = withMethod(ctx, "CreatePRComment")
 	opt := sdk.PullRequestCommentPostParam{Body: comment}
-	_, _, err := c.ac.PullRequestsApi.PostV5ReposOwnerRepoPullsNumberComments(
-		context.Background(), org, repo, number, opt)
-	return formatErr(err, "create comment of pr")
+	_, r, err := c.ac.PullRequestsApi.PostV5ReposOwnerRepoPullsNumberComments(
+		ctx, org, repo, number, opt)
+	return formatErr(err, r, "create comment of pr")
 }
 
-func (c *client) UpdatePRComment(org, repo string, commentID int32, comment string) error {
+func (c *client) UpdatePRComment(ctx context.Context, org, repo string, commentID int32, comment string) error {
+	ctx = withMethod(ctx, "UpdatePRComment")
 	opt := sdk.PullRequestCommentPatchParam{Body: comment}
-	_, _, err := c.ac.PullRequestsApi.PatchV5ReposOwnerRepoPullsCommentsId(
-		context.Background(), org, repo, commentID, opt)
-	return formatErr(err, "update comment of pr")
+	_, r, err := c.ac.PullRequestsApi.PatchV5ReposOwnerRepoPullsCommentsId(
+		ctx, org, repo, commentID, opt)
+	return formatErr(err, r, "update comment of pr")
 }
 
-func (c *client) AddPRLabel(org, repo string, number int32, label string) error {
-	return c.AddMultiPRLabel(org, repo, number, []string{label})
+func (c *client) AddPRLabel(ctx context.Context, org, repo string, number int32, label string) error {
+	ctx = withMethod(ctx, "AddPRLabel")
+	return c.AddMultiPRLabel(ctx, org, repo, number, []string{label})
 }
 
-func (c *client) AddMultiPRLabel(org, repo string, number int32, label []string) error {
+func (c *client) AddMultiPRLabel(ctx context.Context, org, repo string, number int32, label []string) error {
+	ctx = withMethod(ctx, "AddMultiPRLabel")
 	opt := sdk.PullRequestLabelPostParam{Body: label}
-	_, _, err := c.ac.PullRequestsApi.PostV5ReposOwnerRepoPullsNumberLabels(
-		context.Background(), org, repo, number, opt)
-	return formatErr(err, "add multi label for pr")
+	_, r, err := c.ac.PullRequestsApi.PostV5ReposOwnerRepoPullsNumberLabels(
+		ctx, org, repo, number, opt)
+	return formatErr(err, r, "add multi label for pr")
 }
 
-func (c *client) RemovePRLabel(org, repo string, number int32, label string) error {
+func (c *client) RemovePRLabel(ctx context.Context, org, repo string, number int32, label string) error {
+	ctx = withMethod(ctx, "RemovePRLabel")
 	// gitee's bug, it can't deal with the label which includes '/'
 	label = strings.Replace(label, "/", "%2F", -1)
 
-	v, err := c.ac.PullRequestsApi.DeleteV5ReposOwnerRepoPullsLabel(
-		context.Background(), org, repo, number, label, nil)
+	r, err := c.ac.PullRequestsApi.DeleteV5ReposOwnerRepoPullsLabel(
+		ctx, org, repo, number, label, nil)
 
-	if err == nil || (v != nil && v.StatusCode == 404) {
+	if err = formatErr(err, r, "remove label of pr"); IsNotFound(err) {
 		return nil
 	}
-	return formatErr(err, "remove label of pr")
+	return err
 }
 
-func (c *client) RemovePRLabels(org, repo string, number int32, labels []string) error {
-	return c.RemovePRLabel(org, repo, number, strings.Join(labels, ","))
+func (c *client) RemovePRLabels(ctx context.Context, org, repo string, number int32, labels []string) error {
+	ctx = withMethod(ctx, "RemovePRLabels")
+	return c.RemovePRLabel(ctx, org, repo, number, strings.Join(labels, ","))
 }
 
-func (c *client) ClosePR(org, repo string, number int32) error {
+func (c *client) ClosePR(ctx context.Context, org, repo string, number int32) error {
+	ctx = withMethod(ctx, "ClosePR")
 	opt := sdk.PullRequestUpdateParam{State: StatusClosed}
-	_, err := c.UpdatePullRequest(org, repo, number, opt)
-	return formatErr(err, "close pr")
+	_, err := c.UpdatePullRequest(ctx, org, repo, number, opt)
+	return err
 }
 
-func (c *client) AssignPR(org, repo string, number int32, logins []string) error {
+func (c *client) AssignPR(ctx context.Context, org, repo string, number int32, logins []string) error {
+	ctx = withMethod(ctx, "AssignPR")
 	opt := sdk.PullRequestAssigneePostParam{Assignees: strings.Join(logins, ",")}
-	_, _, err := c.ac.PullRequestsApi.PostV5ReposOwnerRepoPullsNumberAssignees(
-		context.Background(), org, repo, number, opt)
-	return formatErr(err, "assign reviewer to pr")
+	_, r, err := c.ac.PullRequestsApi.PostV5ReposOwnerRepoPullsNumberAssignees(
+		ctx, org, repo, number, opt)
+	return formatErr(err, r, "assign reviewer to pr")
 }
 
-func (c *client) UnassignPR(org, repo string, number int32, logins []string) error {
-	_, _, err := c.ac.PullRequestsApi.DeleteV5ReposOwnerRepoPullsNumberAssignees(
-		context.Background(), org, repo, number, strings.Join(logins, ","), nil)
-	return formatErr(err, "unassign reviewer from pr")
+func (c *client) UnassignPR(ctx context.Context, org, repo string, number int32, logins []string) error {
+	ctx = withMethod(ctx, "UnassignPR")
+	_, r, err := c.ac.PullRequestsApi.DeleteV5ReposOwnerRepoPullsNumberAssignees(
+		ctx, org, repo, number, strings.Join(logins, ","), nil)
+	return formatErr(err, r, "unassign reviewer from pr")
 }
 
-func (c *client) GetPRCommits(org, repo string, number int32) ([]sdk.PullRequestCommits, error) {
-	commits, _, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberCommits(
-		context.Background(), org, repo, number, nil)
-	return commits, formatErr(err, "get pr commits")
+func (c *client) GetPRCommits(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestCommits, error) {
+	ctx = withMethod(ctx, "GetPRCommits")
+	commits, r, err := c.ac.PullRequestsApi.GetV5ReposOwnerRepoPullsNumberCommits(
+		ctx, org, repo, number, nil)
+	return commits, formatErr(err, r, "get pr commits")
 }
 
-func (c *client) AssignGiteeIssue(org, repo string, number string, login string) error {
+func (c *client) AssignGiteeIssue(ctx context.Context, org, repo string, number string, login string) error {
+	ctx = withMethod(ctx, "AssignGiteeIssue")
 	opt := sdk.IssueUpdateParam{Repo: repo, Assignee: login}
-	_, v, err := c.ac.IssuesApi.PatchV5ReposOwnerIssuesNumber(
-		context.Background(), org, number, opt)
+	_, r, err := c.ac.IssuesApi.PatchV5ReposOwnerIssuesNumber(
+		ctx, org, number, opt)
 
-	if err != nil {
-		if v.StatusCode == 403 {
-			return ErrorForbidden{err: formatErr(err, "assign assignee to issue").Error()}
-		}
+	if err = formatErr(err, r, "assign assignee to issue"); IsForbidden(err) {
+		return ErrorForbidden{err: err.Error()}
 	}
-	return formatErr(err, "assign assignee to issue")
+	return err
 }
 
-func (c *client) UnassignGiteeIssue(org, repo string, number string, login string) error {
-	return c.AssignGiteeIssue(org, repo, number, " ")
+func (c *client) UnassignGiteeIssue(ctx context.Context, org, repo string, number string, login string) error {
+	ctx = withMethod(ctx, "UnassignGiteeIssue")
+	return c.AssignGiteeIssue(ctx, org, repo, number, " ")
 }
 
-func (c *client) CreateIssueComment(org, repo string, number string, comment string) error {
+func (c *client) CreateIssueComment(ctx context.Context, org, repo string, number string, comment string) error {
+	ctx = withMethod(ctx, "CreateIssueComment")
 	opt := sdk.IssueCommentPostParam{Body: comment}
-	_, _, err := c.ac.IssuesApi.PostV5ReposOwnerRepoIssuesNumberComments(
-		context.Background(), org, repo, number, opt)
-	return formatErr(err, "create issue comment")
+	_, r, err := c.ac.IssuesApi.PostV5ReposOwnerRepoIssuesNumberComments(
+		ctx, org, repo, number, opt)
+	return formatErr(err, r, "create issue comment")
 }
 
-func (c *client) IsCollaborator(owner, repo, login string) (bool, error) {
-	v, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoCollaboratorsUsername(
-		context.Background(), owner, repo, login, nil)
+func (c *client) IsCollaborator(ctx context.Context, owner, repo, login string) (bool, error) {
+	ctx = withMethod(ctx, "IsCollaborator")
+	r, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoCollaboratorsUsername(
+		ctx, owner, repo, login, nil)
 	if err == nil {
 		return true, nil
 	}
 
-	if v != nil && v.StatusCode == 404 {
+	if err = formatErr(err, r, "get collaborator of pr"); IsNotFound(err) {
 		return false, nil
 	}
-	return false, formatErr(err, "get collaborator of pr")
+	return false, err
 }
 
-func (c *client) IsMember(org, login string) (bool, error) {
-	_, v, err := c.ac.OrganizationsApi.GetV5OrgsOrgMembershipsUsername(
-		context.Background(), org, login, nil)
+func (c *client) IsMember(ctx context.Context, org, login string) (bool, error) {
+	ctx = withMethod(ctx, "IsMember")
+	_, r, err := c.ac.OrganizationsApi.GetV5OrgsOrgMembershipsUsername(
+		ctx, org, login, nil)
 	if err == nil {
 		return true, nil
 	}
 
-	if v != nil && v.StatusCode == 404 {
+	if err = formatErr(err, r, "get member of org"); IsNotFound(err) {
 		return false, nil
 	}
-	return false, formatErr(err, "get member of org")
+	return false, err
 }
 
-func (c *client) GetPRCommit(org, repo, SHA string) (sdk.RepoCommit, error) {
-	v, _, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoCommitsSha(
-		context.Background(), org, repo, SHA, nil)
+func (c *client) GetPRCommit(ctx context.Context, org, repo, SHA string) (sdk.RepoCommit, error) {
+	ctx = withMethod(ctx, "GetPRCommit")
+	v, r, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoCommitsSha(
+		ctx, org, repo, SHA, nil)
 	if err != nil {
-		return v, formatErr(err, "get commit info")
+		return v, formatErr(err, r, "get commit info")
 	}
 
 	return v, nil
 }
 
-func (c *client) MergePR(owner, repo string, number int32, opt sdk.PullRequestMergePutParam) error {
-	_, err := c.ac.PullRequestsApi.PutV5ReposOwnerRepoPullsNumberMerge(
-		context.Background(), owner, repo, number, opt)
-	return formatErr(err, "merge pr")
+func (c *client) MergePR(ctx context.Context, owner, repo string, number int32, opt sdk.PullRequestMergePutParam) error {
+	ctx = withMethod(ctx, "MergePR")
+	r, err := c.ac.PullRequestsApi.PutV5ReposOwnerRepoPullsNumberMerge(
+		ctx, owner, repo, number, opt)
+	return formatErr(err, r, "merge pr")
 }
 
-func (c *client) GetGiteeRepo(org, repo string) (sdk.Project, error) {
-	v, _, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepo(context.Background(), org, repo, nil)
-	return v, formatErr(err, "get repo")
+func (c *client) GetGiteeRepo(ctx context.Context, org, repo string) (sdk.Project, error) {
+	ctx = withMethod(ctx, "GetGiteeRepo")
+	v, r, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepo(ctx, org, repo, nil)
+	return v, formatErr(err, r, "get repo")
 }
 
-func (c *client) GetRepo(org, repo string) (sdk.Project, error) {
-	return c.GetGiteeRepo(org, repo)
+func (c *client) GetRepo(ctx context.Context, org, repo string) (sdk.Project, error) {
+	ctx = withMethod(ctx, "GetRepo")
+	return c.GetGiteeRepo(ctx, org, repo)
 }
 
-func (c *client) GetRepos(org string) ([]sdk.Project, error) {
-	opt := sdk.GetV5OrgsOrgReposOpts{}
+func (c *client) GetRepos(ctx context.Context, org string) ([]sdk.Project, error) {
+	ctx = withMethod(ctx, "GetRepos")
 	var r []sdk.Project
-	p := int32(1)
-	for {
-		opt.Page = optional.NewInt32(p)
-		ps, _, err := c.ac.RepositoriesApi.GetV5OrgsOrgRepos(context.Background(), org, &opt)
+	err := c.IterateRepos(ctx, org, func(v sdk.Project) error {
+		r = append(r, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *client) IterateRepos(ctx context.Context, org string, handle func(sdk.Project) error) error {
+	ctx = withMethod(ctx, "IterateRepos")
+	opt := sdk.GetV5OrgsOrgReposOpts{}
+
+	return Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		ps, resp, err := c.ac.RepositoriesApi.GetV5OrgsOrgRepos(ctx, org, &opt)
 		if err != nil {
-			return nil, formatErr(err, "list repos")
+			return 0, formatErr(err, resp, "list repos")
 		}
 
-		if len(ps) == 0 {
-			break
+		for i := range ps {
+			if err := handle(ps[i]); err != nil {
+				return 0, err
+			}
 		}
-		r = append(r, ps...)
-		p++
-	}
-
-	return r, nil
+		return len(ps), nil
+	})
 }
 
-func (c *client) GetRepoLabels(owner, repo string) ([]sdk.Label, error) {
-	labels, _, err := c.ac.LabelsApi.GetV5ReposOwnerRepoLabels(context.Background(), owner, repo, nil)
-	return labels, formatErr(err, "get repo labels")
+func (c *client) GetRepoLabels(ctx context.Context, owner, repo string) ([]sdk.Label, error) {
+	ctx = withMethod(ctx, "GetRepoLabels")
+	labels, r, err := c.ac.LabelsApi.GetV5ReposOwnerRepoLabels(ctx, owner, repo, nil)
+	return labels, formatErr(err, r, "get repo labels")
 }
 
-func (c *client) AddIssueLabel(org, repo, number, label string) error {
+func (c *client) AddIssueLabel(ctx context.Context, org, repo, number, label string) error {
+	ctx = withMethod(ctx, "AddIssueLabel")
 	opt := sdk.PullRequestLabelPostParam{Body: []string{label}}
-	_, _, err := c.ac.LabelsApi.PostV5ReposOwnerRepoIssuesNumberLabels(
-		context.Background(), org, repo, number, opt)
-	return formatErr(err, "add issue label")
+	_, r, err := c.ac.LabelsApi.PostV5ReposOwnerRepoIssuesNumberLabels(
+		ctx, org, repo, number, opt)
+	return formatErr(err, r, "add issue label")
 }
 
-func (c *client) AddMultiIssueLabel(org, repo, number string, label []string) error {
+func (c *client) AddMultiIssueLabel(ctx context.Context, org, repo, number string, label []string) error {
+	ctx = withMethod(ctx, "AddMultiIssueLabel")
 	opt := sdk.PullRequestLabelPostParam{Body: label}
-	_, _, err := c.ac.LabelsApi.PostV5ReposOwnerRepoIssuesNumberLabels(
-		context.Background(), org, repo, number, opt)
-	return formatErr(err, "add issue label")
+	_, r, err := c.ac.LabelsApi.PostV5ReposOwnerRepoIssuesNumberLabels(
+		ctx, org, repo, number, opt)
+	return formatErr(err, r, "add issue label")
 }
 
-func (c *client) RemoveIssueLabel(org, repo, number, label string) error {
+func (c *client) RemoveIssueLabel(ctx context.Context, org, repo, number, label string) error {
+	ctx = withMethod(ctx, "RemoveIssueLabel")
 	label = strings.Replace(label, "/", "%2F", -1)
-	_, err := c.ac.LabelsApi.DeleteV5ReposOwnerRepoIssuesNumberLabelsName(
-		context.Background(), org, repo, number, label, nil)
-	return formatErr(err, "rm issue label")
+	r, err := c.ac.LabelsApi.DeleteV5ReposOwnerRepoIssuesNumberLabelsName(
+		ctx, org, repo, number, label, nil)
+	return formatErr(err, r, "rm issue label")
 }
 
-func (c *client) RemoveIssueLabels(org, repo, number string, label []string) error {
-	return c.RemoveIssueLabel(org, repo, number, strings.Join(label, ","))
+func (c *client) RemoveIssueLabels(ctx context.Context, org, repo, number string, label []string) error {
+	ctx = withMethod(ctx, "RemoveIssueLabels")
+	return c.RemoveIssueLabel(ctx, org, repo, number, strings.Join(label, ","))
 }
 
-func (c *client) ReplacePRAllLabels(owner, repo string, number int32, labels []string) error {
+func (c *client) ReplacePRAllLabels(ctx context.Context, owner, repo string, number int32, labels []string) error {
+	ctx = withMethod(ctx, "ReplacePRAllLabels")
 	opt := sdk.PullRequestLabelPostParam{Body: labels}
-	_, _, err := c.ac.PullRequestsApi.PutV5ReposOwnerRepoPullsNumberLabels(context.Background(), owner, repo, number, opt)
-	return formatErr(err, "replace pr labels")
+	_, r, err := c.ac.PullRequestsApi.PutV5ReposOwnerRepoPullsNumberLabels(ctx, owner, repo, number, opt)
+	return formatErr(err, r, "replace pr labels")
 }
 
-func (c *client) CloseIssue(owner, repo string, number string) error {
+func (c *client) CloseIssue(ctx context.Context, owner, repo string, number string) error {
+	ctx = withMethod(ctx, "CloseIssue")
 	opt := sdk.IssueUpdateParam{Repo: repo, State: StatusClosed}
-	_, err := c.UpdateIssue(owner, number, opt)
-	return formatErr(err, "close issue")
+	_, err := c.UpdateIssue(ctx, owner, number, opt)
+	return err
 }
 
-func (c *client) ReopenIssue(owner, repo string, number string) error {
+func (c *client) ReopenIssue(ctx context.Context, owner, repo string, number string) error {
+	ctx = withMethod(ctx, "ReopenIssue")
 	opt := sdk.IssueUpdateParam{Repo: repo, State: StatusOpen}
-	_, err := c.UpdateIssue(owner, number, opt)
-	return formatErr(err, "reopen issue")
+	_, err := c.UpdateIssue(ctx, owner, number, opt)
+	return err
 }
 
-func (c *client) UpdateIssue(owner, number string, param sdk.IssueUpdateParam) (sdk.Issue, error) {
-	issue, _, err := c.ac.IssuesApi.PatchV5ReposOwnerIssuesNumber(context.Background(), owner, number, param)
-	return issue, formatErr(err, "update issue")
+func (c *client) UpdateIssue(ctx context.Context, owner, number string, param sdk.IssueUpdateParam) (sdk.Issue, error) {
+	ctx = withMethod(ctx, "UpdateIssue")
+	issue, r, err := c.ac.IssuesApi.PatchV5ReposOwnerIssuesNumber(ctx, owner, number, param)
+	return issue, formatErr(err, r, "update issue")
 }
 
-func (c *client) GetIssueLabels(org, repo, number string) ([]sdk.Label, error) {
-	labels, _, err := c.ac.LabelsApi.GetV5ReposOwnerRepoIssuesNumberLabels(context.Background(), org, repo, number, nil)
-	return labels, formatErr(err, "get issue labels")
+func (c *client) GetIssueLabels(ctx context.Context, org, repo, number string) ([]sdk.Label, error) {
+	ctx = withMethod(ctx, "GetIssueLabels")
+	labels, r, err := c.ac.LabelsApi.GetV5ReposOwnerRepoIssuesNumberLabels(ctx, org, repo, number, nil)
+	return labels, formatErr(err, r, "get issue labels")
 }
 
-func (c *client) UpdateIssueComment(org, repo string, commentID int32, comment string) error {
+func (c *client) UpdateIssueComment(ctx context.Context, org, repo string, commentID int32, comment string) error {
+	ctx = withMethod(ctx, "UpdateIssueComment")
 	opt := sdk.IssueCommentPatchParam{Body: comment}
-	_, _, err := c.ac.IssuesApi.PatchV5ReposOwnerRepoIssuesCommentsId(
-		context.Background(), org, repo, commentID, opt)
-	return formatErr(err, "update comment of issue")
-}
+	_, r, err := c.ac.IssuesApi.PatchV5ReposOwnerRepoIssuesCommentsId(
+		ctx, org, repo, commentID, opt)
+	return formatErr(err, r, "update comment of issue")
+}
+
+func (c *client) GetIssue(ctx context.Context, org, repo, number string) (sdk.Issue, error) {
+	ctx = withMethod(ctx, "GetIssue")
+	issue, r, err := c.ac.IssuesApi.GetV5ReposOwnerRepoIssuesNumber(ctx, org, repo, number, nil)
+	return issue, formatErr(err, r, "get issue")
+}
+
+func (c *client) CreateIssue(ctx context.Context, org, repo, title string, opts CreateIssueOpt) (sdk.Issue, error) {
+	ctx = withMethod(ctx, "CreateIssue")
+	param := sdk.IssueCreateParam{
+		Repo:      repo,
+		Title:     title,
+		Body:      opts.Body,
+		Assignee:  opts.Assignee,
+		Milestone: opts.MilestoneNumber,
+		Labels:    strings.Join(opts.Labels, ","),
+		IssueType: opts.IssueType,
+	}
 
-func (c *client) GetIssue(org, repo, number string) (sdk.Issue, error) {
-	issue, _, err := c.ac.IssuesApi.GetV5ReposOwnerRepoIssuesNumber(context.Background(), org, repo, number, nil)
-	return issue, formatErr(err, "get issue")
+	issue, r, err := c.ac.IssuesApi.PostV5ReposOwnerIssues(ctx, org, param)
+	return issue, formatErr(err, r, "create issue")
 }
 
-func (c *client) ListIssueComments(org, repo, number string) ([]sdk.Note, error) {
-	var r []sdk.Note
+func (c *client) ListIssues(ctx context.Context, org, repo string, opts ListIssueOpt) ([]sdk.Issue, error) {
+	ctx = withMethod(ctx, "ListIssues")
 
-	p := int32(1)
-	opt := sdk.GetV5ReposOwnerRepoIssuesNumberCommentsOpts{}
-	for {
-		opt.Page = optional.NewInt32(p)
-		cs, _, err := c.ac.IssuesApi.GetV5ReposOwnerRepoIssuesNumberComments(
-			context.Background(), org, repo, number, &opt)
+	opt := sdk.GetV5ReposOwnerRepoIssuesOpts{}
+	setStr(&opt.State, opts.State)
+	setStr(&opt.Labels, strings.Join(opts.Labels, ","))
+	setStr(&opt.Creator, opts.Creator)
+	setStr(&opt.Assignee, opts.Assignee)
+	setStr(&opt.Sort, opts.Sort)
+	setStr(&opt.Direction, opts.Direction)
+	if !opts.Since.IsZero() {
+		opt.Since = optional.NewString(opts.Since.Format(time.RFC3339))
+	}
+
+	var r []sdk.Issue
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		issues, resp, err := c.ac.IssuesApi.GetV5ReposOwnerRepoIssues(ctx, org, repo, &opt)
 		if err != nil {
-			return nil, formatErr(err, "list comments of issue")
+			return 0, formatErr(err, resp, "list issues")
 		}
 
-		if len(cs) == 0 {
-			break
-		}
+		r = append(r, issues...)
+		return len(issues), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *client) ListIssueComments(ctx context.Context, org, repo, number string) ([]sdk.Note, error) {
+	ctx = withMethod(ctx, "ListIssueComments")
+	var r []sdk.Note
 
-		r = append(r, cs...)
-		p++
+	err := c.IterateIssueComments(ctx, org, repo, number, func(v sdk.Note) error {
+		r = append(r, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return r, nil
 }
 
+func (c *client) IterateIssueComments(ctx context.Context, org, repo, number string, handle func(sdk.Note) error) error {
+	ctx = withMethod(ctx, "IterateIssueComments")
+	opt := sdk.GetV5ReposOwnerRepoIssuesNumberCommentsOpts{}
+
+	return Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		cs, resp, err := c.ac.IssuesApi.GetV5ReposOwnerRepoIssuesNumberComments(
+			ctx, org, repo, number, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "list comments of issue")
+		}
+
+		for i := range cs {
+			if err := handle(cs[i]); err != nil {
+				return 0, err
+			}
+		}
+		return len(cs), nil
+	})
+}
+
 //GetRepoAllBranch get repository all branch
-func (c *client) GetRepoAllBranch(org, repo string) ([]sdk.Branch, error) {
-	branches, _, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoBranches(context.Background(), org, repo,
+func (c *client) GetRepoAllBranch(ctx context.Context, org, repo string) ([]sdk.Branch, error) {
+	ctx = withMethod(ctx, "GetRepoAllBranch")
+	branches, r, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoBranches(ctx, org, repo,
 		nil)
-	return branches, formatErr(err, "get repo all branch")
+	return branches, formatErr(err, r, "get repo all branch")
 }
 
 //GetPathContent Get the content under a specific repository
-func (c *client) GetPathContent(org, repo, path, ref string) (sdk.Content, error) {
+func (c *client) GetPathContent(ctx context.Context, org, repo, path, ref string) (sdk.Content, error) {
+	ctx = withMethod(ctx, "GetPathContent")
 	op := sdk.GetV5ReposOwnerRepoContentsPathOpts{}
 	op.Ref = optional.NewString(ref)
 
-	content, _, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoContentsPath(
-		context.Background(), org, repo, path, &op,
+	content, r, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoContentsPath(
+		ctx, org, repo, path, &op,
 	)
 	if err != nil {
-		return content, formatErr(err, "get path content")
+		return content, formatErr(err, r, "get path content")
 	}
 
 	if content.DownloadUrl == "" {
-		return content, formatErr(fmt.Errorf("file does not exist"), "get path content")
+		return content, &Error{
+			Op:         "get path content",
+			StatusCode: http.StatusNotFound,
+			err:        errors.New("file does not exist"),
+		}
 	}
 
 	return content, nil
 }
 
-func (c *client) CreateFile(org, repo, branch, path, content, commitMsg string) (sdk.CommitContent, error) {
+func (c *client) CreateFile(ctx context.Context, org, repo, branch, path, content, commitMsg string) (sdk.CommitContent, error) {
+	ctx = withMethod(ctx, "CreateFile")
 	opt := sdk.NewFileParam{
 		Message: commitMsg,
 		Branch:  branch,
 		Content: base64.StdEncoding.EncodeToString([]byte(content)),
 	}
 
-	v, _, err := c.ac.RepositoriesApi.PostV5ReposOwnerRepoContentsPath(
-		context.Background(), org, repo, path, opt,
+	v, r, err := c.ac.RepositoriesApi.PostV5ReposOwnerRepoContentsPath(
+		ctx, org, repo, path, opt,
 	)
 
-	return v, formatErr(err, "create file")
+	return v, formatErr(err, r, "create file")
 }
 
 //GetDirectoryTree Get the directory tree under a specific repository branch or commit sha
-func (c *client) GetDirectoryTree(org, repo, sha string, recursive int32) (sdk.Tree, error) {
+func (c *client) GetDirectoryTree(ctx context.Context, org, repo, sha string, recursive int32) (sdk.Tree, error) {
+	ctx = withMethod(ctx, "GetDirectoryTree")
 	op := sdk.GetV5ReposOwnerRepoGitTreesShaOpts{Recursive: optional.NewInt32(recursive)}
-	trees, _, err := c.ac.GitDataApi.GetV5ReposOwnerRepoGitTreesSha(context.Background(), org, repo, sha, &op)
-	return trees, formatErr(err, "get directory tree")
+	trees, r, err := c.ac.GitDataApi.GetV5ReposOwnerRepoGitTreesSha(ctx, org, repo, sha, &op)
+	return trees, formatErr(err, r, "get directory tree")
 }
 
 // GetUserPermissionsOfRepo get user permissions in the repository
-func (c *client) GetUserPermissionsOfRepo(org, repo, login string) (sdk.ProjectMemberPermission, error) {
-	permission, _, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoCollaboratorsUsernamePermission(
-		context.Background(), org, repo, login, nil,
+func (c *client) GetUserPermissionsOfRepo(ctx context.Context, org, repo, login string) (sdk.ProjectMemberPermission, error) {
+	ctx = withMethod(ctx, "GetUserPermissionsOfRepo")
+	permission, r, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoCollaboratorsUsernamePermission(
+		ctx, org, repo, login, nil,
 	)
 
-	return permission, formatErr(err, "get user permissions")
+	return permission, formatErr(err, r, "get user permissions")
 }
 
 // CreateRepoLabel create label for the repository
-func (c *client) CreateRepoLabel(org, repo, label, color string) error {
+func (c *client) CreateRepoLabel(ctx context.Context, org, repo, label, color string) error {
+	ctx = withMethod(ctx, "CreateRepoLabel")
 	if color == "" {
 		color = genrateRGBColor()
 	}
@@ -552,92 +726,87 @@ func (c *client) CreateRepoLabel(org, repo, label, color string) error {
 		Color: color,
 	}
 
-	_, _, err := c.ac.LabelsApi.PostV5ReposOwnerRepoLabels(context.Background(), org, repo, param)
+	_, r, err := c.ac.LabelsApi.PostV5ReposOwnerRepoLabels(ctx, org, repo, param)
 
-	return formatErr(err, "create a repo label")
+	return formatErr(err, r, "create a repo label")
 }
 
-func (c *client) CreateBranch(org, repo, branch, parentBranch string) error {
-	_, _, err := c.ac.RepositoriesApi.PostV5ReposOwnerRepoBranches(
-		context.Background(), org, repo,
+func (c *client) CreateBranch(ctx context.Context, org, repo, branch, parentBranch string) error {
+	ctx = withMethod(ctx, "CreateBranch")
+	_, r, err := c.ac.RepositoriesApi.PostV5ReposOwnerRepoBranches(
+		ctx, org, repo,
 		sdk.CreateBranchParam{BranchName: branch, Refs: parentBranch},
 	)
 
-	return formatErr(err, "create a branch")
+	return formatErr(err, r, "create a branch")
 }
 
-func (c *client) SetProtectionBranch(org, repo, branch string) error {
-	_, _, err := c.ac.RepositoriesApi.PutV5ReposOwnerRepoBranchesBranchProtection(
-		context.Background(), org, repo, branch, sdk.BranchProtectionPutParam{},
+func (c *client) SetProtectionBranch(ctx context.Context, org, repo, branch string) error {
+	ctx = withMethod(ctx, "SetProtectionBranch")
+	_, r, err := c.ac.RepositoriesApi.PutV5ReposOwnerRepoBranchesBranchProtection(
+		ctx, org, repo, branch, sdk.BranchProtectionPutParam{},
 	)
 
-	return formatErr(err, "set protection branch")
+	return formatErr(err, r, "set protection branch")
 }
 
-func (c *client) CancelProtectionBranch(org, repo, branch string) error {
-	_, err := c.ac.RepositoriesApi.DeleteV5ReposOwnerRepoBranchesBranchProtection(
-		context.Background(), org, repo, branch,
+func (c *client) CancelProtectionBranch(ctx context.Context, org, repo, branch string) error {
+	ctx = withMethod(ctx, "CancelProtectionBranch")
+	r, err := c.ac.RepositoriesApi.DeleteV5ReposOwnerRepoBranchesBranchProtection(
+		ctx, org, repo, branch,
 		&sdk.DeleteV5ReposOwnerRepoBranchesBranchProtectionOpts{},
 	)
 
-	return formatErr(err, "cancel protection branch")
+	return formatErr(err, r, "cancel protection branch")
 }
 
-func (c *client) AddRepoMember(org, repo, login, permission string) error {
-	_, _, err := c.ac.RepositoriesApi.PutV5ReposOwnerRepoCollaboratorsUsername(
-		context.Background(), org, repo, login,
+func (c *client) AddRepoMember(ctx context.Context, org, repo, login, permission string) error {
+	ctx = withMethod(ctx, "AddRepoMember")
+	_, r, err := c.ac.RepositoriesApi.PutV5ReposOwnerRepoCollaboratorsUsername(
+		ctx, org, repo, login,
 		sdk.ProjectMemberPutParam{
 			Permission: permission,
 		},
 	)
 
-	return formatErr(err, "add repo member")
+	return formatErr(err, r, "add repo member")
 }
 
-func (c *client) RemoveRepoMember(org, repo, login string) error {
-	v, err := c.ac.RepositoriesApi.DeleteV5ReposOwnerRepoCollaboratorsUsername(
-		context.Background(), org, repo, login, nil,
+func (c *client) RemoveRepoMember(ctx context.Context, org, repo, login string) error {
+	ctx = withMethod(ctx, "RemoveRepoMember")
+	r, err := c.ac.RepositoriesApi.DeleteV5ReposOwnerRepoCollaboratorsUsername(
+		ctx, org, repo, login, nil,
 	)
 
-	if err == nil || (v != nil && v.StatusCode == 404) {
+	if err = formatErr(err, r, "remove repo member"); IsNotFound(err) {
 		return nil
 	}
-	return formatErr(err, "remove repo member")
+	return err
 }
 
-func (c *client) CreateRepo(org string, repo sdk.RepositoryPostParam) error {
-	_, _, err := c.ac.RepositoriesApi.PostV5OrgsOrgRepos(
-		context.Background(), org, repo,
+func (c *client) CreateRepo(ctx context.Context, org string, repo sdk.RepositoryPostParam) error {
+	ctx = withMethod(ctx, "CreateRepo")
+	_, r, err := c.ac.RepositoriesApi.PostV5OrgsOrgRepos(
+		ctx, org, repo,
 	)
 
-	return formatErr(err, "create repo")
+	return formatErr(err, r, "create repo")
 }
 
-func (c *client) SetRepoReviewer(org, repo string, reviewer sdk.SetRepoReviewer) error {
-	_, err := c.ac.RepositoriesApi.PutV5ReposOwnerRepoReviewer(
-		context.Background(), org, repo, reviewer,
+func (c *client) SetRepoReviewer(ctx context.Context, org, repo string, reviewer sdk.SetRepoReviewer) error {
+	ctx = withMethod(ctx, "SetRepoReviewer")
+	r, err := c.ac.RepositoriesApi.PutV5ReposOwnerRepoReviewer(
+		ctx, org, repo, reviewer,
 	)
 
-	return formatErr(err, "set repo reviewer")
+	return formatErr(err, r, "set repo reviewer")
 }
 
-func (c *client) UpdateRepo(org, repo string, info sdk.RepoPatchParam) error {
-	_, _, err := c.ac.RepositoriesApi.PatchV5ReposOwnerRepo(
-		context.Background(), org, repo, info,
+func (c *client) UpdateRepo(ctx context.Context, org, repo string, info sdk.RepoPatchParam) error {
+	ctx = withMethod(ctx, "UpdateRepo")
+	_, r, err := c.ac.RepositoriesApi.PatchV5ReposOwnerRepo(
+		ctx, org, repo, info,
 	)
 
-	return formatErr(err, "update repo")
-}
-
-func formatErr(err error, doWhat string) error {
-	if err == nil {
-		return err
-	}
-
-	var msg []byte
-	if v, ok := err.(sdk.GenericSwaggerError); ok {
-		msg = v.Body()
-	}
-
-	return fmt.Errorf("failed to %s, err: %s, msg: %q", doWhat, err.Error(), msg)
+	return formatErr(err, r, "update repo")
 }
@@ -0,0 +1,499 @@
+package giteeclient
+
+import (
+	"context"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
+
+var _ Client = clientAdapter{}
+
+// clientAdapter implements Client by calling ContextClient with context.Background().
+type clientAdapter struct {
+	c ContextClient
+}
+
+// WrapContextClient adapts a ContextClient to Client.
+func WrapContextClient(c ContextClient) Client {
+	return clientAdapter{c: c}
+}
+
+func (a clientAdapter) CreatePullRequest(org, repo, title, body, head, base string, canModify bool) (sdk.PullRequest, error) {
+	return a.c.CreatePullRequest(context.Background(), org, repo, title, body, head, base, canModify)
+}
+
+func (a clientAdapter) GetPullRequests(org, repo string, opts ListPullRequestOpt) ([]sdk.PullRequest, error) {
+	return a.c.GetPullRequests(context.Background(), org, repo, opts)
+}
+
+func (a clientAdapter) UpdatePullRequest(org, repo string, number int32, param sdk.PullRequestUpdateParam) (sdk.PullRequest, error) {
+	return a.c.UpdatePullRequest(context.Background(), org, repo, number, param)
+}
+
+func (a clientAdapter) ListCollaborators(org, repo string) ([]sdk.ProjectMember, error) {
+	return a.c.ListCollaborators(context.Background(), org, repo)
+}
+
+func (a clientAdapter) IterateCollaborators(org, repo string, handle func(sdk.ProjectMember) error) error {
+	return a.c.IterateCollaborators(context.Background(), org, repo, handle)
+}
+
+func (a clientAdapter) IsCollaborator(owner, repo, login string) (bool, error) {
+	return a.c.IsCollaborator(context.Background(), owner, repo, login)
+}
+
+func (a clientAdapter) IsMember(org, login string) (bool, error) {
+	return a.c.IsMember(context.Background(), org, login)
+}
+
+func (a clientAdapter) RemoveRepoMember(org, repo, login string) error {
+	return a.c.RemoveRepoMember(context.Background(), org, repo, login)
+}
+
+func (a clientAdapter) AddRepoMember(org, repo, login, permission string) error {
+	return a.c.AddRepoMember(context.Background(), org, repo, login, permission)
+}
+
+func (a clientAdapter) GetRef(org, repo, ref string) (string, error) {
+	return a.c.GetRef(context.Background(), org, repo, ref)
+}
+
+func (a clientAdapter) GetPullRequestChanges(org, repo string, number int32) ([]sdk.PullRequestFiles, error) {
+	return a.c.GetPullRequestChanges(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) GetPRLabels(org, repo string, number int32) ([]sdk.Label, error) {
+	return a.c.GetPRLabels(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) IteratePRLabels(org, repo string, number int32, handle func(sdk.Label) error) error {
+	return a.c.IteratePRLabels(context.Background(), org, repo, number, handle)
+}
+
+func (a clientAdapter) ListPRComments(org, repo string, number int32) ([]sdk.PullRequestComments, error) {
+	return a.c.ListPRComments(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) IteratePRComments(org, repo string, number int32, handle func(sdk.PullRequestComments) error) error {
+	return a.c.IteratePRComments(context.Background(), org, repo, number, handle)
+}
+
+func (a clientAdapter) ListPrIssues(org, repo string, number int32) ([]sdk.Issue, error) {
+	return a.c.ListPrIssues(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) DeletePRComment(org, repo string, ID int32) error {
+	return a.c.DeletePRComment(context.Background(), org, repo, ID)
+}
+
+func (a clientAdapter) CreatePRComment(org, repo string, number int32, comment string) error {
+	return a.c.CreatePRComment(context.Background(), org, repo, number, comment)
+}
+
+func (a clientAdapter) UpdatePRComment(org, repo string, commentID int32, comment string) error {
+	return a.c.UpdatePRComment(context.Background(), org, repo, commentID, comment)
+}
+
+func (a clientAdapter) AddPRLabel(org, repo string, number int32, label string) error {
+	return a.c.AddPRLabel(context.Background(), org, repo, number, label)
+}
+
+func (a clientAdapter) AddMultiPRLabel(org, repo string, number int32, label []string) error {
+	return a.c.AddMultiPRLabel(context.Background(), org, repo, number, label)
+}
+
+func (a clientAdapter) RemovePRLabel(org, repo string, number int32, label string) error {
+	return a.c.RemovePRLabel(context.Background(), org, repo, number, label)
+}
+
+func (a clientAdapter) RemovePRLabels(org, repo string, number int32, labels []string) error {
+	return a.c.RemovePRLabels(context.Background(), org, repo, number, labels)
+}
+
+func (a clientAdapter) ReplacePRAllLabels(owner, repo string, number int32, labels []string) error {
+	return a.c.ReplacePRAllLabels(context.Background(), owner, repo, number, labels)
+}
+
+func (a clientAdapter) ListPROperationLogs(org, repo string, number int32) ([]sdk.OperateLog, error) {
+	return a.c.ListPROperationLogs(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) ClosePR(org, repo string, number int32) error {
+	return a.c.ClosePR(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) AssignPR(owner, repo string, number int32, logins []string) error {
+	return a.c.AssignPR(context.Background(), owner, repo, number, logins)
+}
+
+func (a clientAdapter) UnassignPR(owner, repo string, number int32, logins []string) error {
+	return a.c.UnassignPR(context.Background(), owner, repo, number, logins)
+}
+
+func (a clientAdapter) GetPRCommits(org, repo string, number int32) ([]sdk.PullRequestCommits, error) {
+	return a.c.GetPRCommits(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) GetGiteePullRequest(org, repo string, number int32) (sdk.PullRequest, error) {
+	return a.c.GetGiteePullRequest(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) GetPRCommit(org, repo, SHA string) (sdk.RepoCommit, error) {
+	return a.c.GetPRCommit(context.Background(), org, repo, SHA)
+}
+
+func (a clientAdapter) MergePR(owner, repo string, number int32, opt sdk.PullRequestMergePutParam) error {
+	return a.c.MergePR(context.Background(), owner, repo, number, opt)
+}
+
+func (a clientAdapter) GetRepos(org string) ([]sdk.Project, error) {
+	return a.c.GetRepos(context.Background(), org)
+}
+
+func (a clientAdapter) IterateRepos(org string, handle func(sdk.Project) error) error {
+	return a.c.IterateRepos(context.Background(), org, handle)
+}
+
+func (a clientAdapter) CreateRepo(org string, repo sdk.RepositoryPostParam) error {
+	return a.c.CreateRepo(context.Background(), org, repo)
+}
+
+func (a clientAdapter) UpdateRepo(org, repo string, info sdk.RepoPatchParam) error {
+	return a.c.UpdateRepo(context.Background(), org, repo, info)
+}
+
+func (a clientAdapter) GetRepo(org, repo string) (sdk.Project, error) {
+	return a.c.GetRepo(context.Background(), org, repo)
+}
+
+func (a clientAdapter) GetGiteeRepo(org, repo string) (sdk.Project, error) {
+	return a.c.GetGiteeRepo(context.Background(), org, repo)
+}
+
+func (a clientAdapter) SetRepoReviewer(org, repo string, reviewer sdk.SetRepoReviewer) error {
+	return a.c.SetRepoReviewer(context.Background(), org, repo, reviewer)
+}
+
+func (a clientAdapter) CreateRepoLabel(org, repo, label, color string) error {
+	return a.c.CreateRepoLabel(context.Background(), org, repo, label, color)
+}
+
+func (a clientAdapter) GetRepoLabels(owner, repo string) ([]sdk.Label, error) {
+	return a.c.GetRepoLabels(context.Background(), owner, repo)
+}
+
+func (a clientAdapter) AssignGiteeIssue(org, repo string, number string, login string) error {
+	return a.c.AssignGiteeIssue(context.Background(), org, repo, number, login)
+}
+
+func (a clientAdapter) UnassignGiteeIssue(org, repo string, number string, login string) error {
+	return a.c.UnassignGiteeIssue(context.Background(), org, repo, number, login)
+}
+
+func (a clientAdapter) CreateIssueComment(org, repo string, number string, comment string) error {
+	return a.c.CreateIssueComment(context.Background(), org, repo, number, comment)
+}
+
+func (a clientAdapter) UpdateIssueComment(org, repo string, commentID int32, comment string) error {
+	return a.c.UpdateIssueComment(context.Background(), org, repo, commentID, comment)
+}
+
+func (a clientAdapter) ListIssueComments(org, repo, number string) ([]sdk.Note, error) {
+	return a.c.ListIssueComments(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) IterateIssueComments(org, repo, number string, handle func(sdk.Note) error) error {
+	return a.c.IterateIssueComments(context.Background(), org, repo, number, handle)
+}
+
+func (a clientAdapter) GetIssueLabels(org, repo, number string) ([]sdk.Label, error) {
+	return a.c.GetIssueLabels(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) RemoveIssueLabel(org, repo, number, label string) error {
+	return a.c.RemoveIssueLabel(context.Background(), org, repo, number, label)
+}
+
+func (a clientAdapter) RemoveIssueLabels(org, repo, number string, label []string) error {
+	return a.c.RemoveIssueLabels(context.Background(), org, repo, number, label)
+}
+
+func (a clientAdapter) AddIssueLabel(org, repo, number, label string) error {
+	return a.c.AddIssueLabel(context.Background(), org, repo, number, label)
+}
+
+func (a clientAdapter) AddMultiIssueLabel(org, repo, number string, label []string) error {
+	return a.c.AddMultiIssueLabel(context.Background(), org, repo, number, label)
+}
+
+func (a clientAdapter) CloseIssue(owner, repo string, number string) error {
+	return a.c.CloseIssue(context.Background(), owner, repo, number)
+}
+
+func (a clientAdapter) ReopenIssue(owner, repo string, number string) error {
+	return a.c.ReopenIssue(context.Background(), owner, repo, number)
+}
+
+func (a clientAdapter) UpdateIssue(owner, number string, param sdk.IssueUpdateParam) (sdk.Issue, error) {
+	return a.c.UpdateIssue(context.Background(), owner, number, param)
+}
+
+func (a clientAdapter) GetIssue(org, repo, number string) (sdk.Issue, error) {
+	return a.c.GetIssue(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) CreateBranch(org, repo, branch, parentBranch string) error {
+	return a.c.CreateBranch(context.Background(), org, repo, branch, parentBranch)
+}
+
+func (a clientAdapter) GetRepoAllBranch(org, repo string) ([]sdk.Branch, error) {
+	return a.c.GetRepoAllBranch(context.Background(), org, repo)
+}
+
+func (a clientAdapter) SetProtectionBranch(org, repo, branch string) error {
+	return a.c.SetProtectionBranch(context.Background(), org, repo, branch)
+}
+
+func (a clientAdapter) CancelProtectionBranch(org, repo, branch string) error {
+	return a.c.CancelProtectionBranch(context.Background(), org, repo, branch)
+}
+
+func (a clientAdapter) CreateFile(org, repo, branch, path, content, commitMsg string) (sdk.CommitContent, error) {
+	return a.c.CreateFile(context.Background(), org, repo, branch, path, content, commitMsg)
+}
+
+func (a clientAdapter) GetPathContent(org, repo, path, ref string) (sdk.Content, error) {
+	return a.c.GetPathContent(context.Background(), org, repo, path, ref)
+}
+
+func (a clientAdapter) GetDirectoryTree(org, repo, sha string, recursive int32) (sdk.Tree, error) {
+	return a.c.GetDirectoryTree(context.Background(), org, repo, sha, recursive)
+}
+
+func (a clientAdapter) GetBot() (sdk.User, error) {
+	return a.c.GetBot(context.Background())
+}
+
+func (a clientAdapter) GetUserPermissionsOfRepo(org, repo, login string) (sdk.ProjectMemberPermission, error) {
+	return a.c.GetUserPermissionsOfRepo(context.Background(), org, repo, login)
+}
+
+func (a clientAdapter) ListMilestones(org, repo, state string) ([]sdk.Milestone, error) {
+	return a.c.ListMilestones(context.Background(), org, repo, state)
+}
+
+func (a clientAdapter) GetMilestone(org, repo string, number int32) (sdk.Milestone, error) {
+	return a.c.GetMilestone(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) CreateMilestone(org, repo string, param sdk.MilestonePostParam) (sdk.Milestone, error) {
+	return a.c.CreateMilestone(context.Background(), org, repo, param)
+}
+
+func (a clientAdapter) UpdateMilestone(org, repo string, number int32, param sdk.MilestonePatchParam) (sdk.Milestone, error) {
+	return a.c.UpdateMilestone(context.Background(), org, repo, number, param)
+}
+
+func (a clientAdapter) CloseMilestone(org, repo string, number int32) error {
+	return a.c.CloseMilestone(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) SetIssueMilestone(org, repo, number string, milestone int32) error {
+	return a.c.SetIssueMilestone(context.Background(), org, repo, number, milestone)
+}
+
+func (a clientAdapter) ClearIssueMilestone(org, repo, number string) error {
+	return a.c.ClearIssueMilestone(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) SetPRMilestone(org, repo string, number, milestone int32) error {
+	return a.c.SetPRMilestone(context.Background(), org, repo, number, milestone)
+}
+
+func (a clientAdapter) ClearPRMilestone(org, repo string, number int32) error {
+	return a.c.ClearPRMilestone(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) ListRepoHooks(org, repo string) ([]Hook, error) {
+	return a.c.ListRepoHooks(context.Background(), org, repo)
+}
+
+func (a clientAdapter) CreateRepoHook(org, repo string, param HookParam) (Hook, error) {
+	return a.c.CreateRepoHook(context.Background(), org, repo, param)
+}
+
+func (a clientAdapter) UpdateRepoHook(org, repo string, id int32, param HookParam) (Hook, error) {
+	return a.c.UpdateRepoHook(context.Background(), org, repo, id, param)
+}
+
+func (a clientAdapter) TestRepoHook(org, repo string, id int32) error {
+	return a.c.TestRepoHook(context.Background(), org, repo, id)
+}
+
+func (a clientAdapter) DeleteRepoHook(org, repo string, id int32) error {
+	return a.c.DeleteRepoHook(context.Background(), org, repo, id)
+}
+
+func (a clientAdapter) CreateIssue(org, repo, title string, opts CreateIssueOpt) (sdk.Issue, error) {
+	return a.c.CreateIssue(context.Background(), org, repo, title, opts)
+}
+
+func (a clientAdapter) ListIssues(org, repo string, opts ListIssueOpt) ([]sdk.Issue, error) {
+	return a.c.ListIssues(context.Background(), org, repo, opts)
+}
+
+func (a clientAdapter) AssignPRTesters(org, repo string, number int32, logins []string) error {
+	return a.c.AssignPRTesters(context.Background(), org, repo, number, logins)
+}
+
+func (a clientAdapter) UnassignPRTesters(org, repo string, number int32, logins []string) error {
+	return a.c.UnassignPRTesters(context.Background(), org, repo, number, logins)
+}
+
+func (a clientAdapter) ApprovePRReview(org, repo string, number int32, force bool) error {
+	return a.c.ApprovePRReview(context.Background(), org, repo, number, force)
+}
+
+func (a clientAdapter) PassPRTest(org, repo string, number int32, force bool) error {
+	return a.c.PassPRTest(context.Background(), org, repo, number, force)
+}
+
+func (a clientAdapter) ResetPRReview(org, repo string, number int32, resetAll bool) error {
+	return a.c.ResetPRReview(context.Background(), org, repo, number, resetAll)
+}
+
+func (a clientAdapter) ResetPRTest(org, repo string, number int32, resetAll bool) error {
+	return a.c.ResetPRTest(context.Background(), org, repo, number, resetAll)
+}
+
+func (a clientAdapter) GetPRReviewState(org, repo string, number int32) (PRReviewState, error) {
+	return a.c.GetPRReviewState(context.Background(), org, repo, number)
+}
+
+func (a clientAdapter) UpdateFile(org, repo, branch, path, content, sha, commitMsg string) (sdk.CommitContent, error) {
+	return a.c.UpdateFile(context.Background(), org, repo, branch, path, content, sha, commitMsg)
+}
+
+func (a clientAdapter) DeleteFile(org, repo, branch, path, sha, commitMsg string) (sdk.CommitContent, error) {
+	return a.c.DeleteFile(context.Background(), org, repo, branch, path, sha, commitMsg)
+}
+
+func (a clientAdapter) CommitFiles(org, repo, branch, commitMsg string, changes []FileChange) (sdk.RepoCommit, error) {
+	return a.c.CommitFiles(context.Background(), org, repo, branch, commitMsg, changes)
+}
+
+func (a clientAdapter) ListBranchProtectionRules(org, repo string) ([]BranchProtectionRule, error) {
+	return a.c.ListBranchProtectionRules(context.Background(), org, repo)
+}
+
+func (a clientAdapter) GetBranchProtectionRule(org, repo, wildcard string) (BranchProtectionRule, error) {
+	return a.c.GetBranchProtectionRule(context.Background(), org, repo, wildcard)
+}
+
+func (a clientAdapter) CreateBranchProtectionRule(org, repo string, rule BranchProtectionRule) (BranchProtectionRule, error) {
+	return a.c.CreateBranchProtectionRule(context.Background(), org, repo, rule)
+}
+
+func (a clientAdapter) UpdateBranchProtectionRule(org, repo, wildcard string, rule BranchProtectionRule) (BranchProtectionRule, error) {
+	return a.c.UpdateBranchProtectionRule(context.Background(), org, repo, wildcard, rule)
+}
+
+func (a clientAdapter) DeleteBranchProtectionRule(org, repo, wildcard string) error {
+	return a.c.DeleteBranchProtectionRule(context.Background(), org, repo, wildcard)
+}
+
+func (a clientAdapter) ListOrgMembers(org, role string) ([]OrgMember, error) {
+	return a.c.ListOrgMembers(context.Background(), org, role)
+}
+
+func (a clientAdapter) SetOrgMember(org, login, role string) error {
+	return a.c.SetOrgMember(context.Background(), org, login, role)
+}
+
+func (a clientAdapter) RemoveOrgMember(org, login string) error {
+	return a.c.RemoveOrgMember(context.Background(), org, login)
+}
+
+func (a clientAdapter) ListEnterpriseMembers(enterprise string) ([]EnterpriseMember, error) {
+	return a.c.ListEnterpriseMembers(context.Background(), enterprise)
+}
+
+func (a clientAdapter) ListUserOrgs(login string) ([]sdk.Group, error) {
+	return a.c.ListUserOrgs(context.Background(), login)
+}
+
+func (a clientAdapter) ListBotOrgs() ([]sdk.Group, error) {
+	return a.c.ListBotOrgs(context.Background())
+}
+
+func (a clientAdapter) ListReleases(org, repo string) ([]Release, error) {
+	return a.c.ListReleases(context.Background(), org, repo)
+}
+
+func (a clientAdapter) GetRelease(org, repo string, id int32) (Release, error) {
+	return a.c.GetRelease(context.Background(), org, repo, id)
+}
+
+func (a clientAdapter) GetReleaseByTag(org, repo, tag string) (Release, error) {
+	return a.c.GetReleaseByTag(context.Background(), org, repo, tag)
+}
+
+func (a clientAdapter) CreateRelease(org, repo string, param ReleaseParam) (Release, error) {
+	return a.c.CreateRelease(context.Background(), org, repo, param)
+}
+
+func (a clientAdapter) UpdateRelease(org, repo string, id int32, param ReleaseParam) (Release, error) {
+	return a.c.UpdateRelease(context.Background(), org, repo, id, param)
+}
+
+func (a clientAdapter) DeleteRelease(org, repo string, id int32) error {
+	return a.c.DeleteRelease(context.Background(), org, repo, id)
+}
+
+func (a clientAdapter) ListTags(org, repo string) ([]Tag, error) {
+	return a.c.ListTags(context.Background(), org, repo)
+}
+
+func (a clientAdapter) CreateTag(org, repo, tag, ref string) (Tag, error) {
+	return a.c.CreateTag(context.Background(), org, repo, tag, ref)
+}
+
+func (a clientAdapter) GetUser(login string) (sdk.User, error) {
+	return a.c.GetUser(context.Background(), login)
+}
+
+func (a clientAdapter) GetUserEmail(login string) (string, error) {
+	return a.c.GetUserEmail(context.Background(), login)
+}
+
+func (a clientAdapter) ListBotEmails() ([]UserEmail, error) {
+	return a.c.ListBotEmails(context.Background())
+}
+
+func (a clientAdapter) SearchRepos(q string, opts SearchRepoOpt) ([]sdk.Project, error) {
+	return a.c.SearchRepos(context.Background(), q, opts)
+}
+
+func (a clientAdapter) SearchIssues(q string, opts SearchIssueOpt) ([]sdk.Issue, error) {
+	return a.c.SearchIssues(context.Background(), q, opts)
+}
+
+func (a clientAdapter) SearchUsers(q string, opts SearchUserOpt) ([]sdk.User, error) {
+	return a.c.SearchUsers(context.Background(), q, opts)
+}
+
+func (a clientAdapter) ForkRepo(org, repo, namespace string) (sdk.Project, error) {
+	return a.c.ForkRepo(context.Background(), org, repo, namespace)
+}
+
+func (a clientAdapter) ListForks(org, repo string) ([]sdk.Project, error) {
+	return a.c.ListForks(context.Background(), org, repo)
+}
+
+func (a clientAdapter) GetBotFork(org, repo string) (sdk.Project, error) {
+	return a.c.GetBotFork(context.Background(), org, repo)
+}
+
+func (a clientAdapter) WaitForFork(org, repo string) (sdk.Project, error) {
+	return a.c.WaitForFork(context.Background(), org, repo)
+}
@@ -0,0 +1,154 @@
+package giteeclient
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+// ClientOptions holds the options to create a client.
+type ClientOptions struct {
+	// BaseURL is the address of Gitee API, such as https://gitee.com/api.
+	// The default address of sdk will be used if it is empty.
+	BaseURL string
+
+	// UserAgent is set to the header of every request if it is not empty.
+	UserAgent string
+
+	// Timeout is the time limit for each try of a request, including reading
+	// the response body. The waiting time between the tries is not counted.
+	// Zero means no timeout.
+	Timeout time.Duration
+
+	// QPS is the maximum number of requests sent per second. Zero means no limit.
+	QPS float64
+
+	// Limiter bounds the requests in flight and the rate of requests if it is
+	// not nil. Unlike QPS, it can be shared by several clients.
+	Limiter *Limiter
+
+	// PerPage is the page size used by the list methods. DefaultPerPage will be
+	// used if it is zero, and it is capped at MaxPerPage.
+	PerPage int32
+
+	// RetryPolicy is the policy to retry the failed requests.
+	// The zero value means no retry.
+	RetryPolicy RetryPolicy
+
+	// Transport is the underlying RoundTripper to send the request.
+	// http.DefaultTransport will be used if it is nil.
+	Transport http.RoundTripper
+
+	// Breaker fails the requests fast while Gitee is unavailable if it is not nil.
+	Breaker *Breaker
+
+	// UserCacheTTL is how long GetUser caches a user. Zero means no cache.
+	UserCacheTTL time.Duration
+
+	// Metrics collects the metrics of requests if it is not nil.
+	Metrics *Metrics
+}
+
+func (o *ClientOptions) newConfiguration(getToken func() []byte) *sdk.Configuration {
+	ts := newTokenSource(getToken)
+
+	auth := func(base http.RoundTripper) http.RoundTripper {
+		return &oauth2.Transport{Source: ts, Base: base}
+	}
+	return o.newConfigurationWithAuth(auth, false)
+}
+
+// newConfigurationWithAuth creates the configuration whose requests are
+// authorized by the transport which auth returns. Every try of a request is
+// authorized separately if eachTry is true, so that a retry can use another token.
+func (o *ClientOptions) newConfigurationWithAuth(auth func(http.RoundTripper) http.RoundTripper, eachTry bool) *sdk.Configuration {
+	conf := sdk.NewConfiguration()
+
+	if o.BaseURL != "" {
+		conf.BasePath = o.BaseURL
+	}
+
+	if o.UserAgent != "" {
+		conf.UserAgent = o.UserAgent
+	}
+
+	base := o.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if o.QPS > 0 {
+		base = newQPSTransport(o.QPS, base)
+	}
+
+	if o.Limiter != nil {
+		base = &limiterTransport{limiter: o.Limiter, base: base}
+	}
+
+	if eachTry {
+		base = auth(base)
+	}
+
+	if o.Timeout > 0 {
+		base = &timeoutTransport{timeout: o.Timeout, base: base}
+	}
+
+	if o.RetryPolicy.MaxRetries > 0 {
+		base = newRetryTransport(o.RetryPolicy, base)
+	}
+
+	// The breaker judges a request after all its tries.
+	if o.Breaker != nil {
+		base = &breakerTransport{breaker: o.Breaker, base: base}
+	}
+
+	if o.Metrics != nil {
+		base = &metricsTransport{metrics: o.Metrics, base: base}
+	}
+
+	if !eachTry {
+		base = auth(base)
+	}
+
+	conf.HTTPClient = &http.Client{Transport: base}
+
+	return conf
+}
+
+// NewClientWithOptions creates a client with options.
+func NewClientWithOptions(getToken func() []byte, opts ClientOptions) Client {
+	return WrapContextClient(NewContextClient(getToken, opts))
+}
+
+// qpsTransport limits the rate of requests by a token bucket.
+type qpsTransport struct {
+	limiter *rate.Limiter
+	base    http.RoundTripper
+}
+
+func newQPSTransport(qps float64, base http.RoundTripper) *qpsTransport {
+	burst := int(math.Ceil(qps))
+
+	return &qpsTransport{
+		limiter: rate.NewLimiter(rate.Limit(qps), burst),
+		base:    base,
+	}
+}
+
+func (t *qpsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := t.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,886 @@
+package giteeclient
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"github.com/sirupsen/logrus"
+)
+
+var _ ContextClient = (*DryRunClient)(nil)
+
+// PlannedAction is a mutating call blocked by DryRunClient.
+type PlannedAction struct {
+	// Method is the name of the method called, such as "CreatePRComment".
+	Method string
+
+	// Args are the arguments of the call keyed by the parameter names.
+	Args map[string]interface{}
+
+	Time time.Time
+}
+
+// DryRunClient passes the reads through to the wrapped client and blocks
+// all the mutating calls. The blocked calls are logged and recorded as
+// planned actions, and they return fake but plausible values, so that a
+// plugin can run against production without changing anything.
+// Use WrapContextClient to get a Client.
+type DryRunClient struct {
+	c ContextClient
+
+	mut     sync.Mutex
+	lastID  int32
+	actions []PlannedAction
+}
+
+// NewDryRunClient wraps c which is used to read only.
+func NewDryRunClient(c ContextClient) *DryRunClient {
+	return &DryRunClient{c: c}
+}
+
+// PlannedActions returns the blocked calls in the order they were made.
+func (d *DryRunClient) PlannedActions() []PlannedAction {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	r := make([]PlannedAction, len(d.actions))
+	copy(r, d.actions)
+	return r
+}
+
+func (d *DryRunClient) plan(method string, args logrus.Fields) {
+	a := PlannedAction{Method: method, Args: args, Time: time.Now()}
+
+	d.mut.Lock()
+	d.actions = append(d.actions, a)
+	d.mut.Unlock()
+
+	logrus.WithFields(args).WithField("method", method).Info("dry run, skip the mutating call")
+}
+
+// nextID returns the synthesized id for the objects "created" in dry run.
+func (d *DryRunClient) nextID() int32 {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	d.lastID++
+	return d.lastID
+}
+
+func (d *DryRunClient) CreatePullRequest(ctx context.Context, org, repo, title, body, head, base string, canModify bool) (sdk.PullRequest, error) {
+	d.plan("CreatePullRequest", logrus.Fields{
+		"org": org, "repo": repo, "title": title, "body": body,
+		"head": head, "base": base, "can_modify": canModify,
+	})
+
+	id := d.nextID()
+	return sdk.PullRequest{
+		Id:        id,
+		Number:    id,
+		State:     StatusOpen,
+		Title:     title,
+		Body:      body,
+		Head:      &sdk.BranchBasic{Ref: head, Label: head},
+		Base:      &sdk.BranchBasic{Ref: base, Label: base},
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+func (d *DryRunClient) UpdatePullRequest(ctx context.Context, org, repo string, number int32, param sdk.PullRequestUpdateParam) (sdk.PullRequest, error) {
+	d.plan("UpdatePullRequest", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "param": param,
+	})
+
+	// The pr is read to make the result plausible, and it is fine to fail.
+	pr, err := d.c.GetGiteePullRequest(ctx, org, repo, number)
+	if err != nil {
+		pr = sdk.PullRequest{Number: number}
+	}
+
+	if param.Title != "" {
+		pr.Title = param.Title
+	}
+	if param.Body != "" {
+		pr.Body = param.Body
+	}
+	if param.State != "" {
+		pr.State = param.State
+	}
+	if param.Labels != "" {
+		pr.Labels = nil
+		for _, l := range strings.Split(param.Labels, ",") {
+			pr.Labels = append(pr.Labels, sdk.Label{Name: strings.TrimSpace(l)})
+		}
+	}
+	return pr, nil
+}
+
+func (d *DryRunClient) RemoveRepoMember(ctx context.Context, org, repo, login string) error {
+	d.plan("RemoveRepoMember", logrus.Fields{"org": org, "repo": repo, "login": login})
+	return nil
+}
+
+func (d *DryRunClient) AddRepoMember(ctx context.Context, org, repo, login, permission string) error {
+	d.plan("AddRepoMember", logrus.Fields{
+		"org": org, "repo": repo, "login": login, "permission": permission,
+	})
+	return nil
+}
+
+func (d *DryRunClient) SetOrgMember(ctx context.Context, org, login, role string) error {
+	d.plan("SetOrgMember", logrus.Fields{"org": org, "login": login, "role": role})
+	return nil
+}
+
+func (d *DryRunClient) RemoveOrgMember(ctx context.Context, org, login string) error {
+	d.plan("RemoveOrgMember", logrus.Fields{"org": org, "login": login})
+	return nil
+}
+
+func (d *DryRunClient) DeletePRComment(ctx context.Context, org, repo string, ID int32) error {
+	d.plan("DeletePRComment", logrus.Fields{"org": org, "repo": repo, "id": ID})
+	return nil
+}
+
+func (d *DryRunClient) CreatePRComment(ctx context.Context, org, repo string, number int32, comment string) error {
+	d.plan("CreatePRComment", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "comment": comment,
+	})
+	return nil
+}
+
+func (d *DryRunClient) UpdatePRComment(ctx context.Context, org, repo string, commentID int32, comment string) error {
+	d.plan("UpdatePRComment", logrus.Fields{
+		"org": org, "repo": repo, "id": commentID, "comment": comment,
+	})
+	return nil
+}
+
+func (d *DryRunClient) AddPRLabel(ctx context.Context, org, repo string, number int32, label string) error {
+	d.plan("AddPRLabel", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "label": label,
+	})
+	return nil
+}
+
+func (d *DryRunClient) AddMultiPRLabel(ctx context.Context, org, repo string, number int32, label []string) error {
+	d.plan("AddMultiPRLabel", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "labels": label,
+	})
+	return nil
+}
+
+func (d *DryRunClient) RemovePRLabel(ctx context.Context, org, repo string, number int32, label string) error {
+	d.plan("RemovePRLabel", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "label": label,
+	})
+	return nil
+}
+
+func (d *DryRunClient) RemovePRLabels(ctx context.Context, org, repo string, number int32, labels []string) error {
+	d.plan("RemovePRLabels", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "labels": labels,
+	})
+	return nil
+}
+
+func (d *DryRunClient) ReplacePRAllLabels(ctx context.Context, owner, repo string, number int32, labels []string) error {
+	d.plan("ReplacePRAllLabels", logrus.Fields{
+		"org": owner, "repo": repo, "number": number, "labels": labels,
+	})
+	return nil
+}
+
+func (d *DryRunClient) ClosePR(ctx context.Context, org, repo string, number int32) error {
+	d.plan("ClosePR", logrus.Fields{"org": org, "repo": repo, "number": number})
+	return nil
+}
+
+func (d *DryRunClient) AssignPR(ctx context.Context, owner, repo string, number int32, logins []string) error {
+	d.plan("AssignPR", logrus.Fields{
+		"org": owner, "repo": repo, "number": number, "logins": logins,
+	})
+	return nil
+}
+
+func (d *DryRunClient) UnassignPR(ctx context.Context, owner, repo string, number int32, logins []string) error {
+	d.plan("UnassignPR", logrus.Fields{
+		"org": owner, "repo": repo, "number": number, "logins": logins,
+	})
+	return nil
+}
+
+func (d *DryRunClient) AssignPRTesters(ctx context.Context, org, repo string, number int32, logins []string) error {
+	d.plan("AssignPRTesters", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "logins": logins,
+	})
+	return nil
+}
+
+func (d *DryRunClient) UnassignPRTesters(ctx context.Context, org, repo string, number int32, logins []string) error {
+	d.plan("UnassignPRTesters", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "logins": logins,
+	})
+	return nil
+}
+
+func (d *DryRunClient) ApprovePRReview(ctx context.Context, org, repo string, number int32, force bool) error {
+	d.plan("ApprovePRReview", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "force": force,
+	})
+	return nil
+}
+
+func (d *DryRunClient) PassPRTest(ctx context.Context, org, repo string, number int32, force bool) error {
+	d.plan("PassPRTest", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "force": force,
+	})
+	return nil
+}
+
+func (d *DryRunClient) ResetPRReview(ctx context.Context, org, repo string, number int32, resetAll bool) error {
+	d.plan("ResetPRReview", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "reset_all": resetAll,
+	})
+	return nil
+}
+
+func (d *DryRunClient) ResetPRTest(ctx context.Context, org, repo string, number int32, resetAll bool) error {
+	d.plan("ResetPRTest", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "reset_all": resetAll,
+	})
+	return nil
+}
+
+func (d *DryRunClient) MergePR(ctx context.Context, owner, repo string, number int32, opt sdk.PullRequestMergePutParam) error {
+	d.plan("MergePR", logrus.Fields{
+		"org": owner, "repo": repo, "number": number, "param": opt,
+	})
+	return nil
+}
+
+func (d *DryRunClient) CreateRepo(ctx context.Context, org string, repo sdk.RepositoryPostParam) error {
+	d.plan("CreateRepo", logrus.Fields{"org": org, "param": repo})
+	return nil
+}
+
+func (d *DryRunClient) UpdateRepo(ctx context.Context, org, repo string, info sdk.RepoPatchParam) error {
+	d.plan("UpdateRepo", logrus.Fields{"org": org, "repo": repo, "param": info})
+	return nil
+}
+
+func (d *DryRunClient) SetRepoReviewer(ctx context.Context, org, repo string, reviewer sdk.SetRepoReviewer) error {
+	d.plan("SetRepoReviewer", logrus.Fields{"org": org, "repo": repo, "param": reviewer})
+	return nil
+}
+
+func (d *DryRunClient) ForkRepo(ctx context.Context, org, repo, namespace string) (sdk.Project, error) {
+	d.plan("ForkRepo", logrus.Fields{"org": org, "repo": repo, "namespace": namespace})
+
+	if namespace == "" {
+		// The bot is read to make the result plausible, and it is fine to fail.
+		if bot, err := d.c.GetBot(ctx); err == nil {
+			namespace = bot.Login
+		}
+	}
+
+	return dryRunFork(d.nextID(), org, repo, namespace), nil
+}
+
+// WaitForFork returns a synthesized fork if it does not exist, because it
+// won't be created in dry run and waiting for it is endless.
+func (d *DryRunClient) WaitForFork(ctx context.Context, org, repo string) (sdk.Project, error) {
+	v, err := d.c.GetRepo(ctx, org, repo)
+	if IsNotFound(err) {
+		return dryRunFork(d.nextID(), "", repo, org), nil
+	}
+	return v, err
+}
+
+func dryRunFork(id int32, org, repo, namespace string) sdk.Project {
+	p := sdk.Project{
+		Id:        id,
+		FullName:  namespace + "/" + repo,
+		Namespace: &sdk.Namespace{Path: namespace, Name: namespace},
+		Path:      repo,
+		Name:      repo,
+		Fork:      true,
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}
+	if org != "" {
+		p.Parent = &sdk.Project{FullName: org + "/" + repo, Path: repo, Name: repo}
+	}
+	return p
+}
+
+func (d *DryRunClient) CreateRepoLabel(ctx context.Context, org, repo, label, color string) error {
+	d.plan("CreateRepoLabel", logrus.Fields{
+		"org": org, "repo": repo, "label": label, "color": color,
+	})
+	return nil
+}
+
+func (d *DryRunClient) CreateRepoHook(ctx context.Context, org, repo string, param HookParam) (Hook, error) {
+	d.plan("CreateRepoHook", logrus.Fields{"org": org, "repo": repo, "url": param.URL})
+
+	return Hook{
+		HookEvents:     param.HookEvents,
+		ID:             d.nextID(),
+		URL:            param.URL,
+		EncryptionType: param.EncryptionType,
+		CreatedAt:      time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+func (d *DryRunClient) UpdateRepoHook(ctx context.Context, org, repo string, id int32, param HookParam) (Hook, error) {
+	d.plan("UpdateRepoHook", logrus.Fields{
+		"org": org, "repo": repo, "id": id, "url": param.URL,
+	})
+
+	return Hook{
+		HookEvents:     param.HookEvents,
+		ID:             id,
+		URL:            param.URL,
+		EncryptionType: param.EncryptionType,
+	}, nil
+}
+
+func (d *DryRunClient) TestRepoHook(ctx context.Context, org, repo string, id int32) error {
+	d.plan("TestRepoHook", logrus.Fields{"org": org, "repo": repo, "id": id})
+	return nil
+}
+
+func (d *DryRunClient) DeleteRepoHook(ctx context.Context, org, repo string, id int32) error {
+	d.plan("DeleteRepoHook", logrus.Fields{"org": org, "repo": repo, "id": id})
+	return nil
+}
+
+func (d *DryRunClient) AssignGiteeIssue(ctx context.Context, org, repo string, number string, login string) error {
+	d.plan("AssignGiteeIssue", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "login": login,
+	})
+	return nil
+}
+
+func (d *DryRunClient) UnassignGiteeIssue(ctx context.Context, org, repo string, number string, login string) error {
+	d.plan("UnassignGiteeIssue", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "login": login,
+	})
+	return nil
+}
+
+func (d *DryRunClient) CreateIssueComment(ctx context.Context, org, repo string, number string, comment string) error {
+	d.plan("CreateIssueComment", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "comment": comment,
+	})
+	return nil
+}
+
+func (d *DryRunClient) UpdateIssueComment(ctx context.Context, org, repo string, commentID int32, comment string) error {
+	d.plan("UpdateIssueComment", logrus.Fields{
+		"org": org, "repo": repo, "id": commentID, "comment": comment,
+	})
+	return nil
+}
+
+func (d *DryRunClient) RemoveIssueLabel(ctx context.Context, org, repo, number, label string) error {
+	d.plan("RemoveIssueLabel", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "label": label,
+	})
+	return nil
+}
+
+func (d *DryRunClient) RemoveIssueLabels(ctx context.Context, org, repo, number string, label []string) error {
+	d.plan("RemoveIssueLabels", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "labels": label,
+	})
+	return nil
+}
+
+func (d *DryRunClient) AddIssueLabel(ctx context.Context, org, repo, number, label string) error {
+	d.plan("AddIssueLabel", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "label": label,
+	})
+	return nil
+}
+
+func (d *DryRunClient) AddMultiIssueLabel(ctx context.Context, org, repo, number string, label []string) error {
+	d.plan("AddMultiIssueLabel", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "labels": label,
+	})
+	return nil
+}
+
+func (d *DryRunClient) CloseIssue(ctx context.Context, owner, repo string, number string) error {
+	d.plan("CloseIssue", logrus.Fields{"org": owner, "repo": repo, "number": number})
+	return nil
+}
+
+func (d *DryRunClient) ReopenIssue(ctx context.Context, owner, repo string, number string) error {
+	d.plan("ReopenIssue", logrus.Fields{"org": owner, "repo": repo, "number": number})
+	return nil
+}
+
+func (d *DryRunClient) UpdateIssue(ctx context.Context, owner, number string, param sdk.IssueUpdateParam) (sdk.Issue, error) {
+	d.plan("UpdateIssue", logrus.Fields{"org": owner, "number": number, "param": param})
+
+	// The issue is read to make the result plausible, and it is fine to fail.
+	issue, err := d.c.GetIssue(ctx, owner, param.Repo, number)
+	if err != nil {
+		issue = sdk.Issue{Number: number}
+	}
+
+	if param.Title != "" {
+		issue.Title = param.Title
+	}
+	if param.Body != "" {
+		issue.Body = param.Body
+	}
+	if param.State != "" {
+		issue.State = param.State
+	}
+	return issue, nil
+}
+
+func (d *DryRunClient) CreateIssue(ctx context.Context, org, repo, title string, opts CreateIssueOpt) (sdk.Issue, error) {
+	d.plan("CreateIssue", logrus.Fields{
+		"org": org, "repo": repo, "title": title, "opts": opts,
+	})
+
+	id := d.nextID()
+	return sdk.Issue{
+		Id:        id,
+		Number:    strconv.Itoa(int(id)),
+		State:     StatusOpen,
+		Title:     title,
+		Body:      opts.Body,
+		IssueType: opts.IssueType,
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+func (d *DryRunClient) CreateMilestone(ctx context.Context, org, repo string, param sdk.MilestonePostParam) (sdk.Milestone, error) {
+	d.plan("CreateMilestone", logrus.Fields{"org": org, "repo": repo, "param": param})
+
+	id := d.nextID()
+	state := param.State
+	if state == "" {
+		state = StatusOpen
+	}
+	return sdk.Milestone{
+		Id:          id,
+		Number:      id,
+		State:       state,
+		Title:       param.Title,
+		Description: param.Description,
+		DueOn:       param.DueOn,
+		CreatedAt:   time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+func (d *DryRunClient) UpdateMilestone(ctx context.Context, org, repo string, number int32, param sdk.MilestonePatchParam) (sdk.Milestone, error) {
+	d.plan("UpdateMilestone", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "param": param,
+	})
+
+	// The milestone is read to make the result plausible, and it is fine to fail.
+	m, err := d.c.GetMilestone(ctx, org, repo, number)
+	if err != nil {
+		m = sdk.Milestone{Number: number}
+	}
+
+	m.Title = param.Title
+	m.DueOn = param.DueOn
+	if param.State != "" {
+		m.State = param.State
+	}
+	if param.Description != "" {
+		m.Description = param.Description
+	}
+	return m, nil
+}
+
+func (d *DryRunClient) CloseMilestone(ctx context.Context, org, repo string, number int32) error {
+	d.plan("CloseMilestone", logrus.Fields{"org": org, "repo": repo, "number": number})
+	return nil
+}
+
+func (d *DryRunClient) SetIssueMilestone(ctx context.Context, org, repo, number string, milestone int32) error {
+	d.plan("SetIssueMilestone", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "milestone": milestone,
+	})
+	return nil
+}
+
+func (d *DryRunClient) ClearIssueMilestone(ctx context.Context, org, repo, number string) error {
+	d.plan("ClearIssueMilestone", logrus.Fields{"org": org, "repo": repo, "number": number})
+	return nil
+}
+
+func (d *DryRunClient) SetPRMilestone(ctx context.Context, org, repo string, number, milestone int32) error {
+	d.plan("SetPRMilestone", logrus.Fields{
+		"org": org, "repo": repo, "number": number, "milestone": milestone,
+	})
+	return nil
+}
+
+func (d *DryRunClient) ClearPRMilestone(ctx context.Context, org, repo string, number int32) error {
+	d.plan("ClearPRMilestone", logrus.Fields{"org": org, "repo": repo, "number": number})
+	return nil
+}
+
+func (d *DryRunClient) CreateBranch(ctx context.Context, org, repo, branch, parentBranch string) error {
+	d.plan("CreateBranch", logrus.Fields{
+		"org": org, "repo": repo, "branch": branch, "parent": parentBranch,
+	})
+	return nil
+}
+
+func (d *DryRunClient) SetProtectionBranch(ctx context.Context, org, repo, branch string) error {
+	d.plan("SetProtectionBranch", logrus.Fields{"org": org, "repo": repo, "branch": branch})
+	return nil
+}
+
+func (d *DryRunClient) CancelProtectionBranch(ctx context.Context, org, repo, branch string) error {
+	d.plan("CancelProtectionBranch", logrus.Fields{"org": org, "repo": repo, "branch": branch})
+	return nil
+}
+
+func (d *DryRunClient) CreateBranchProtectionRule(ctx context.Context, org, repo string, rule BranchProtectionRule) (BranchProtectionRule, error) {
+	d.plan("CreateBranchProtectionRule", logrus.Fields{"org": org, "repo": repo, "rule": rule})
+
+	rule.ID = d.nextID()
+	return rule, nil
+}
+
+func (d *DryRunClient) UpdateBranchProtectionRule(ctx context.Context, org, repo, wildcard string, rule BranchProtectionRule) (BranchProtectionRule, error) {
+	d.plan("UpdateBranchProtectionRule", logrus.Fields{
+		"org": org, "repo": repo, "wildcard": wildcard, "rule": rule,
+	})
+
+	// The rule is read to make the result plausible, and it is fine to fail.
+	if v, err := d.c.GetBranchProtectionRule(ctx, org, repo, wildcard); err == nil {
+		rule.ID = v.ID
+	}
+	return rule, nil
+}
+
+func (d *DryRunClient) DeleteBranchProtectionRule(ctx context.Context, org, repo, wildcard string) error {
+	d.plan("DeleteBranchProtectionRule", logrus.Fields{"org": org, "repo": repo, "wildcard": wildcard})
+	return nil
+}
+
+func (d *DryRunClient) CreateRelease(ctx context.Context, org, repo string, param ReleaseParam) (Release, error) {
+	d.plan("CreateRelease", logrus.Fields{"org": org, "repo": repo, "param": param})
+
+	return Release{
+		ID:              d.nextID(),
+		TagName:         param.TagName,
+		TargetCommitish: param.TargetCommitish,
+		Prerelease:      param.Prerelease,
+		Name:            param.Name,
+		Body:            param.Body,
+		CreatedAt:       time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+func (d *DryRunClient) UpdateRelease(ctx context.Context, org, repo string, id int32, param ReleaseParam) (Release, error) {
+	d.plan("UpdateRelease", logrus.Fields{
+		"org": org, "repo": repo, "id": id, "param": param,
+	})
+
+	// The release is read to make the result plausible, and it is fine to fail.
+	v, err := d.c.GetRelease(ctx, org, repo, id)
+	if err != nil {
+		v = Release{ID: id}
+	}
+
+	v.TagName = param.TagName
+	v.Name = param.Name
+	v.Body = param.Body
+	v.Prerelease = param.Prerelease
+	return v, nil
+}
+
+func (d *DryRunClient) DeleteRelease(ctx context.Context, org, repo string, id int32) error {
+	d.plan("DeleteRelease", logrus.Fields{"org": org, "repo": repo, "id": id})
+	return nil
+}
+
+func (d *DryRunClient) CreateTag(ctx context.Context, org, repo, tag, ref string) (Tag, error) {
+	d.plan("CreateTag", logrus.Fields{"org": org, "repo": repo, "tag": tag, "ref": ref})
+
+	return Tag{Name: tag, Commit: TagCommit{Sha: ref}}, nil
+}
+
+func (d *DryRunClient) CreateFile(ctx context.Context, org, repo, branch, filePath, content, commitMsg string) (sdk.CommitContent, error) {
+	d.plan("CreateFile", logrus.Fields{
+		"org": org, "repo": repo, "branch": branch, "path": filePath,
+		"content": content, "message": commitMsg,
+	})
+
+	return sdk.CommitContent{
+		Content: &sdk.ContentBasic{
+			Name:  path.Base(filePath),
+			Path:  filePath,
+			Size:  int32(len(content)),
+			Type_: "file",
+		},
+		Commit: &sdk.Commit{Message: commitMsg},
+	}, nil
+}
+
+func (d *DryRunClient) UpdateFile(ctx context.Context, org, repo, branch, filePath, content, sha, commitMsg string) (sdk.CommitContent, error) {
+	d.plan("UpdateFile", logrus.Fields{
+		"org": org, "repo": repo, "branch": branch, "path": filePath,
+		"content": content, "sha": sha, "message": commitMsg,
+	})
+
+	return sdk.CommitContent{
+		Content: &sdk.ContentBasic{
+			Name:  path.Base(filePath),
+			Path:  filePath,
+			Size:  int32(len(content)),
+			Type_: "file",
+		},
+		Commit: &sdk.Commit{Message: commitMsg},
+	}, nil
+}
+
+func (d *DryRunClient) DeleteFile(ctx context.Context, org, repo, branch, filePath, sha, commitMsg string) (sdk.CommitContent, error) {
+	d.plan("DeleteFile", logrus.Fields{
+		"org": org, "repo": repo, "branch": branch, "path": filePath,
+		"sha": sha, "message": commitMsg,
+	})
+
+	return sdk.CommitContent{Commit: &sdk.Commit{Message: commitMsg}}, nil
+}
+
+func (d *DryRunClient) CommitFiles(ctx context.Context, org, repo, branch, commitMsg string, changes []FileChange) (sdk.RepoCommit, error) {
+	d.plan("CommitFiles", logrus.Fields{
+		"org": org, "repo": repo, "branch": branch, "message": commitMsg,
+		"changes": changes,
+	})
+
+	return sdk.RepoCommit{Commit: &sdk.GitCommit{Message: commitMsg}}, nil
+}
+
+// The reads below are passed through to the wrapped client. The mutating
+// methods must not be passed through, see TestDryRunClientBlocksWrites.
+
+func (d *DryRunClient) GetPullRequests(ctx context.Context, org, repo string, opts ListPullRequestOpt) ([]sdk.PullRequest, error) {
+	return d.c.GetPullRequests(ctx, org, repo, opts)
+}
+
+func (d *DryRunClient) ListCollaborators(ctx context.Context, org, repo string) ([]sdk.ProjectMember, error) {
+	return d.c.ListCollaborators(ctx, org, repo)
+}
+
+func (d *DryRunClient) IterateCollaborators(ctx context.Context, org, repo string, handle func(sdk.ProjectMember) error) error {
+	return d.c.IterateCollaborators(ctx, org, repo, handle)
+}
+
+func (d *DryRunClient) IsCollaborator(ctx context.Context, owner, repo, login string) (bool, error) {
+	return d.c.IsCollaborator(ctx, owner, repo, login)
+}
+
+func (d *DryRunClient) IsMember(ctx context.Context, org, login string) (bool, error) {
+	return d.c.IsMember(ctx, org, login)
+}
+
+func (d *DryRunClient) ListOrgMembers(ctx context.Context, org, role string) ([]OrgMember, error) {
+	return d.c.ListOrgMembers(ctx, org, role)
+}
+
+func (d *DryRunClient) ListEnterpriseMembers(ctx context.Context, enterprise string) ([]EnterpriseMember, error) {
+	return d.c.ListEnterpriseMembers(ctx, enterprise)
+}
+
+func (d *DryRunClient) ListUserOrgs(ctx context.Context, login string) ([]sdk.Group, error) {
+	return d.c.ListUserOrgs(ctx, login)
+}
+
+func (d *DryRunClient) ListBotOrgs(ctx context.Context) ([]sdk.Group, error) {
+	return d.c.ListBotOrgs(ctx)
+}
+
+func (d *DryRunClient) GetRef(ctx context.Context, org, repo, ref string) (string, error) {
+	return d.c.GetRef(ctx, org, repo, ref)
+}
+
+func (d *DryRunClient) GetPullRequestChanges(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestFiles, error) {
+	return d.c.GetPullRequestChanges(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) GetPRLabels(ctx context.Context, org, repo string, number int32) ([]sdk.Label, error) {
+	return d.c.GetPRLabels(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) IteratePRLabels(ctx context.Context, org, repo string, number int32, handle func(sdk.Label) error) error {
+	return d.c.IteratePRLabels(ctx, org, repo, number, handle)
+}
+
+func (d *DryRunClient) ListPRComments(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestComments, error) {
+	return d.c.ListPRComments(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) IteratePRComments(ctx context.Context, org, repo string, number int32, handle func(sdk.PullRequestComments) error) error {
+	return d.c.IteratePRComments(ctx, org, repo, number, handle)
+}
+
+func (d *DryRunClient) ListPrIssues(ctx context.Context, org, repo string, number int32) ([]sdk.Issue, error) {
+	return d.c.ListPrIssues(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) ListPROperationLogs(ctx context.Context, org, repo string, number int32) ([]sdk.OperateLog, error) {
+	return d.c.ListPROperationLogs(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) GetPRCommits(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestCommits, error) {
+	return d.c.GetPRCommits(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) GetPRReviewState(ctx context.Context, org, repo string, number int32) (PRReviewState, error) {
+	return d.c.GetPRReviewState(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) GetGiteePullRequest(ctx context.Context, org, repo string, number int32) (sdk.PullRequest, error) {
+	return d.c.GetGiteePullRequest(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) GetPRCommit(ctx context.Context, org, repo, SHA string) (sdk.RepoCommit, error) {
+	return d.c.GetPRCommit(ctx, org, repo, SHA)
+}
+
+func (d *DryRunClient) GetRepos(ctx context.Context, org string) ([]sdk.Project, error) {
+	return d.c.GetRepos(ctx, org)
+}
+
+func (d *DryRunClient) IterateRepos(ctx context.Context, org string, handle func(sdk.Project) error) error {
+	return d.c.IterateRepos(ctx, org, handle)
+}
+
+func (d *DryRunClient) GetRepo(ctx context.Context, org, repo string) (sdk.Project, error) {
+	return d.c.GetRepo(ctx, org, repo)
+}
+
+func (d *DryRunClient) GetGiteeRepo(ctx context.Context, org, repo string) (sdk.Project, error) {
+	return d.c.GetGiteeRepo(ctx, org, repo)
+}
+
+func (d *DryRunClient) GetRepoLabels(ctx context.Context, owner, repo string) ([]sdk.Label, error) {
+	return d.c.GetRepoLabels(ctx, owner, repo)
+}
+
+func (d *DryRunClient) ListRepoHooks(ctx context.Context, org, repo string) ([]Hook, error) {
+	return d.c.ListRepoHooks(ctx, org, repo)
+}
+
+func (d *DryRunClient) ListIssueComments(ctx context.Context, org, repo, number string) ([]sdk.Note, error) {
+	return d.c.ListIssueComments(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) IterateIssueComments(ctx context.Context, org, repo, number string, handle func(sdk.Note) error) error {
+	return d.c.IterateIssueComments(ctx, org, repo, number, handle)
+}
+
+func (d *DryRunClient) GetIssueLabels(ctx context.Context, org, repo, number string) ([]sdk.Label, error) {
+	return d.c.GetIssueLabels(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) GetIssue(ctx context.Context, org, repo, number string) (sdk.Issue, error) {
+	return d.c.GetIssue(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) ListIssues(ctx context.Context, org, repo string, opts ListIssueOpt) ([]sdk.Issue, error) {
+	return d.c.ListIssues(ctx, org, repo, opts)
+}
+
+func (d *DryRunClient) ListMilestones(ctx context.Context, org, repo, state string) ([]sdk.Milestone, error) {
+	return d.c.ListMilestones(ctx, org, repo, state)
+}
+
+func (d *DryRunClient) GetMilestone(ctx context.Context, org, repo string, number int32) (sdk.Milestone, error) {
+	return d.c.GetMilestone(ctx, org, repo, number)
+}
+
+func (d *DryRunClient) GetRepoAllBranch(ctx context.Context, org, repo string) ([]sdk.Branch, error) {
+	return d.c.GetRepoAllBranch(ctx, org, repo)
+}
+
+func (d *DryRunClient) ListBranchProtectionRules(ctx context.Context, org, repo string) ([]BranchProtectionRule, error) {
+	return d.c.ListBranchProtectionRules(ctx, org, repo)
+}
+
+func (d *DryRunClient) GetBranchProtectionRule(ctx context.Context, org, repo, wildcard string) (BranchProtectionRule, error) {
+	return d.c.GetBranchProtectionRule(ctx, org, repo, wildcard)
+}
+
+func (d *DryRunClient) ListReleases(ctx context.Context, org, repo string) ([]Release, error) {
+	return d.c.ListReleases(ctx, org, repo)
+}
+
+func (d *DryRunClient) GetRelease(ctx context.Context, org, repo string, id int32) (Release, error) {
+	return d.c.GetRelease(ctx, org, repo, id)
+}
+
+func (d *DryRunClient) GetReleaseByTag(ctx context.Context, org, repo, tag string) (Release, error) {
+	return d.c.GetReleaseByTag(ctx, org, repo, tag)
+}
+
+func (d *DryRunClient) ListTags(ctx context.Context, org, repo string) ([]Tag, error) {
+	return d.c.ListTags(ctx, org, repo)
+}
+
+func (d *DryRunClient) GetPathContent(ctx context.Context, org, repo, path, ref string) (sdk.Content, error) {
+	return d.c.GetPathContent(ctx, org, repo, path, ref)
+}
+
+func (d *DryRunClient) GetDirectoryTree(ctx context.Context, org, repo, sha string, recursive int32) (sdk.Tree, error) {
+	return d.c.GetDirectoryTree(ctx, org, repo, sha, recursive)
+}
+
+func (d *DryRunClient) GetBot(ctx context.Context) (sdk.User, error) {
+	return d.c.GetBot(ctx)
+}
+
+func (d *DryRunClient) GetUserPermissionsOfRepo(ctx context.Context, org, repo, login string) (sdk.ProjectMemberPermission, error) {
+	return d.c.GetUserPermissionsOfRepo(ctx, org, repo, login)
+}
+
+func (d *DryRunClient) GetUser(ctx context.Context, login string) (sdk.User, error) {
+	return d.c.GetUser(ctx, login)
+}
+
+func (d *DryRunClient) GetUserEmail(ctx context.Context, login string) (string, error) {
+	return d.c.GetUserEmail(ctx, login)
+}
+
+func (d *DryRunClient) ListBotEmails(ctx context.Context) ([]UserEmail, error) {
+	return d.c.ListBotEmails(ctx)
+}
+
+func (d *DryRunClient) SearchRepos(ctx context.Context, q string, opts SearchRepoOpt) ([]sdk.Project, error) {
+	return d.c.SearchRepos(ctx, q, opts)
+}
+
+func (d *DryRunClient) SearchIssues(ctx context.Context, q string, opts SearchIssueOpt) ([]sdk.Issue, error) {
+	return d.c.SearchIssues(ctx, q, opts)
+}
+
+func (d *DryRunClient) SearchUsers(ctx context.Context, q string, opts SearchUserOpt) ([]sdk.User, error) {
+	return d.c.SearchUsers(ctx, q, opts)
+}
+
+func (d *DryRunClient) ListForks(ctx context.Context, org, repo string) ([]sdk.Project, error) {
+	return d.c.ListForks(ctx, org, repo)
+}
+
+func (d *DryRunClient) GetBotFork(ctx context.Context, org, repo string) (sdk.Project, error) {
+	return d.c.GetBotFork(ctx, org, repo)
+}
@@ -0,0 +1,126 @@
+package giteeclient
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// dryRunReads are the methods which DryRunClient passes through. A method
+// added to ContextClient must be put here if it only reads, otherwise
+// DryRunClient must block it.
+var dryRunReads = sets.NewString(
+	"GetPullRequests", "GetGiteePullRequest", "GetPullRequestChanges",
+	"GetPRLabels", "IteratePRLabels", "ListPRComments", "IteratePRComments",
+	"ListPrIssues", "ListPROperationLogs", "GetPRCommits", "GetPRCommit",
+	"GetPRReviewState",
+	"ListCollaborators", "IterateCollaborators", "IsCollaborator",
+	"GetUserPermissionsOfRepo",
+	"IsMember", "ListOrgMembers", "ListEnterpriseMembers", "ListUserOrgs", "ListBotOrgs",
+	"GetRepos", "IterateRepos", "GetRepo", "GetGiteeRepo", "GetRepoLabels", "ListRepoHooks",
+	"GetRef", "GetRepoAllBranch", "ListBranchProtectionRules", "GetBranchProtectionRule",
+	"GetPathContent", "GetDirectoryTree",
+	"ListIssueComments", "IterateIssueComments", "GetIssueLabels", "GetIssue", "ListIssues",
+	"ListMilestones", "GetMilestone",
+	"ListReleases", "GetRelease", "GetReleaseByTag", "ListTags",
+	"GetBot", "GetUser", "GetUserEmail", "ListBotEmails",
+	"SearchRepos", "SearchIssues", "SearchUsers",
+	"ListForks", "GetBotFork", "WaitForFork",
+)
+
+// panicClient panics on every call, which tells the call is passed through.
+type panicClient struct {
+	ContextClient
+}
+
+// readOnlyClient implements the reads used by the test and panics on the
+// others, which makes sure the mutating calls are never passed through.
+type readOnlyClient struct {
+	ContextClient
+}
+
+func (c readOnlyClient) GetGiteePullRequest(ctx context.Context, org, repo string, number int32) (sdk.PullRequest, error) {
+	return sdk.PullRequest{Number: number, Title: "fix bug", State: StatusOpen}, nil
+}
+
+func TestDryRunClient(t *testing.T) {
+	d := NewDryRunClient(readOnlyClient{})
+	ctx := context.Background()
+
+	pr, err := d.GetGiteePullRequest(ctx, "org", "repo", 1)
+	if err != nil || pr.Title != "fix bug" {
+		t.Fatalf("the read should be passed through, got: %v, %v", pr, err)
+	}
+
+	if err := d.CreatePRComment(ctx, "org", "repo", 1, "/lgtm"); err != nil {
+		t.Fatal(err)
+	}
+
+	pr, err = d.UpdatePullRequest(ctx, "org", "repo", 1, sdk.PullRequestUpdateParam{State: StatusClosed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Title != "fix bug" || pr.State != StatusClosed {
+		t.Errorf("unexpected synthesized pr: %+v", pr)
+	}
+
+	v, err := d.CreateFile(ctx, "org", "repo", "master", "docs/a.md", "abc", "add a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Content == nil || v.Content.Name != "a.md" || v.Content.Size != 3 {
+		t.Errorf("unexpected synthesized content: %+v", v.Content)
+	}
+
+	actions := d.PlannedActions()
+	if len(actions) != 3 {
+		t.Fatalf("expected 3 planned actions, got: %v", actions)
+	}
+
+	a := actions[0]
+	if a.Method != "CreatePRComment" || a.Args["comment"] != "/lgtm" || a.Args["number"] != int32(1) {
+		t.Errorf("unexpected planned action: %+v", a)
+	}
+}
+
+// TestDryRunClientBlocksWrites calls every method of ContextClient, and makes
+// sure that the reads are passed through and the others are planned.
+func TestDryRunClientBlocksWrites(t *testing.T) {
+	it := reflect.TypeOf((*ContextClient)(nil)).Elem()
+
+	for i := 0; i < it.NumMethod(); i++ {
+		m := it.Method(i)
+
+		d := NewDryRunClient(panicClient{})
+
+		args := []reflect.Value{reflect.ValueOf(context.Background())}
+		for j := 1; j < m.Type.NumIn(); j++ {
+			args = append(args, reflect.Zero(m.Type.In(j)))
+		}
+
+		passed := false
+		func() {
+			defer func() {
+				if recover() != nil {
+					passed = true
+				}
+			}()
+
+			reflect.ValueOf(d).MethodByName(m.Name).Call(args)
+		}()
+
+		planned := len(d.PlannedActions()) > 0
+
+		switch {
+		case dryRunReads.Has(m.Name):
+			if planned || !passed {
+				t.Errorf("%s is a read which should be passed through", m.Name)
+			}
+		case !planned:
+			t.Errorf("%s is neither planned nor listed as a read, it may change the data in dry run", m.Name)
+		}
+	}
+}
@@ -1,5 +1,14 @@
 package giteeclient
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
+
 type ErrorForbidden struct {
 	err string
 }
@@ -7,3 +16,129 @@ type ErrorForbidden struct {
 func (e ErrorForbidden) Error() string {
 	return e.err
 }
+
+// Error is the error returned by the client when it failed to call the Gitee API.
+type Error struct {
+	// Op is the operation the client was doing, such as "create pull request".
+	Op string
+
+	// StatusCode is the status code of the response.
+	// It is 0 if there is no response, such as a network error.
+	StatusCode int
+
+	// Header is the header of the response.
+	Header http.Header
+
+	// Body is the body of the response.
+	Body []byte
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("failed to %s, err: %s, msg: %q", e.Op, e.err.Error(), e.Body)
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// NewError creates an Error with the response of Gitee. The body is
+// in the same format as Gitee, such as {"message":"Not Found"}.
+// It is useful to simulate the errors of Gitee in tests.
+func NewError(op string, statusCode int, message string) *Error {
+	body, _ := json.Marshal(map[string]string{"message": message})
+
+	return &Error{
+		Op:         op,
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       body,
+		err:        fmt.Errorf("%d %s", statusCode, http.StatusText(statusCode)),
+	}
+}
+
+func formatErr(err error, resp *http.Response, doWhat string) error {
+	if err == nil {
+		return err
+	}
+
+	// It has been formatted by the other methods of client.
+	if v, ok := err.(*Error); ok {
+		return v
+	}
+
+	e := &Error{Op: doWhat, err: err}
+
+	if resp != nil {
+		e.StatusCode = resp.StatusCode
+		e.Header = resp.Header
+	}
+
+	if v, ok := err.(sdk.GenericSwaggerError); ok {
+		e.Body = v.Body()
+	}
+
+	return e
+}
+
+// StatusCode returns the status code of the response if err is an Error, otherwise 0.
+func StatusCode(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound returns true if the requested object does not exist.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized returns true if the token is invalid or revoked.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden returns true if the bot has no permission to do the operation.
+func IsForbidden(err error) bool {
+	var v ErrorForbidden
+	if errors.As(err, &v) {
+		return true
+	}
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsConflict returns true if the operation conflicts with the current state
+// of the object, such as merging a pr which has been merged.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsUnprocessable returns true if the request is invalid,
+// such as creating a label which already exists.
+func IsUnprocessable(err error) bool {
+	return StatusCode(err) == http.StatusUnprocessableEntity
+}
+
+// IsRateLimited returns true if the request is rejected because of the rate limit.
+func IsRateLimited(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+
+	switch e.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		return e.Header.Get(headerRateLimitRemaining) == "0"
+	}
+	return false
+}
+
+// IsServerError returns true if Gitee failed to handle the request.
+func IsServerError(err error) bool {
+	return StatusCode(err) >= http.StatusInternalServerError
+}
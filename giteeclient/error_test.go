@@ -0,0 +1,100 @@
+package giteeclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestErrorHelpers(t *testing.T) {
+	newErr := func(code int, header http.Header) error {
+		resp := &http.Response{StatusCode: code, Header: header}
+		return formatErr(errors.New(http.StatusText(code)), resp, "do something")
+	}
+
+	rateLimited := http.Header{}
+	rateLimited.Set(headerRateLimitRemaining, "0")
+
+	testCases := []struct {
+		description string
+		err         error
+		check       func(error) bool
+		expected    bool
+	}{
+		{
+			description: "404 is not found",
+			err:         newErr(http.StatusNotFound, nil),
+			check:       IsNotFound,
+			expected:    true,
+		},
+		{
+			description: "wrapped 404 is not found",
+			err:         fmt.Errorf("handle event: %w", newErr(http.StatusNotFound, nil)),
+			check:       IsNotFound,
+			expected:    true,
+		},
+		{
+			description: "403 is not not found",
+			err:         newErr(http.StatusForbidden, nil),
+			check:       IsNotFound,
+			expected:    false,
+		},
+		{
+			description: "ErrorForbidden is forbidden",
+			err:         ErrorForbidden{err: "forbidden"},
+			check:       IsForbidden,
+			expected:    true,
+		},
+		{
+			description: "409 is conflict",
+			err:         newErr(http.StatusConflict, nil),
+			check:       IsConflict,
+			expected:    true,
+		},
+		{
+			description: "422 is unprocessable",
+			err:         newErr(http.StatusUnprocessableEntity, nil),
+			check:       IsUnprocessable,
+			expected:    true,
+		},
+		{
+			description: "429 is rate limited",
+			err:         newErr(http.StatusTooManyRequests, nil),
+			check:       IsRateLimited,
+			expected:    true,
+		},
+		{
+			description: "403 without remaining quota is rate limited",
+			err:         newErr(http.StatusForbidden, rateLimited),
+			check:       IsRateLimited,
+			expected:    true,
+		},
+		{
+			description: "403 is not rate limited",
+			err:         newErr(http.StatusForbidden, nil),
+			check:       IsRateLimited,
+			expected:    false,
+		},
+		{
+			description: "502 is server error",
+			err:         newErr(http.StatusBadGateway, nil),
+			check:       IsServerError,
+			expected:    true,
+		},
+		{
+			description: "network error is not server error",
+			err:         formatErr(errors.New("connection refused"), nil, "do something"),
+			check:       IsServerError,
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if v := tc.check(tc.err); v != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, v)
+			}
+		})
+	}
+}
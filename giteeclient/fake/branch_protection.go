@@ -0,0 +1,166 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"sort"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+// AddBranchProtectionRule adds a branch protection rule to org/repo and returns its id.
+// The repository is added if it does not exist.
+func (c *Client) AddBranchProtectionRule(org, repo string, rule giteeclient.BranchProtectionRule) int32 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.addRule(c.ensureRepo(org, repo), rule).ID
+}
+
+func (c *Client) addRule(r *repository, rule giteeclient.BranchProtectionRule) giteeclient.BranchProtectionRule {
+	rule.ID = c.nextID()
+	r.rules[rule.Wildcard] = &rule
+	return rule
+}
+
+// matchRule reports whether the branch is protected by any rule.
+func (r *repository) matchRule(branchName string) bool {
+	for w := range r.rules {
+		if ok, _ := path.Match(w, branchName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Client) getRule(ctx context.Context, org, repo, wildcard, op string) (*repository, *giteeclient.BranchProtectionRule, error) {
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule, ok := r.rules[wildcard]
+	if !ok {
+		return nil, nil, notFound(op, "Not Found Rule")
+	}
+	return r, rule, nil
+}
+
+func (c *Client) ListBranchProtectionRules(ctx context.Context, org, repo string) ([]giteeclient.BranchProtectionRule, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "list branch protection rules")
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]giteeclient.BranchProtectionRule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		v = append(v, *rule)
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].ID < v[j].ID
+	})
+	return v, nil
+}
+
+func (c *Client) GetBranchProtectionRule(ctx context.Context, org, repo, wildcard string) (giteeclient.BranchProtectionRule, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, rule, err := c.getRule(ctx, org, repo, wildcard, "get branch protection rule")
+	if err != nil {
+		return giteeclient.BranchProtectionRule{}, err
+	}
+	return *rule, nil
+}
+
+func (c *Client) CreateBranchProtectionRule(ctx context.Context, org, repo string, rule giteeclient.BranchProtectionRule) (giteeclient.BranchProtectionRule, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "create branch protection rule"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return giteeclient.BranchProtectionRule{}, err
+	}
+
+	if err := checkRule(rule, op); err != nil {
+		return giteeclient.BranchProtectionRule{}, err
+	}
+
+	if _, ok := r.rules[rule.Wildcard]; ok {
+		return giteeclient.BranchProtectionRule{}, newError(op, http.StatusUnprocessableEntity, "rule already exists")
+	}
+
+	v := c.addRule(r, rule)
+
+	c.record("CreateBranchProtectionRule", org, repo, rule)
+	return v, nil
+}
+
+func (c *Client) UpdateBranchProtectionRule(ctx context.Context, org, repo, wildcard string, rule giteeclient.BranchProtectionRule) (giteeclient.BranchProtectionRule, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "update branch protection rule"
+
+	r, old, err := c.getRule(ctx, org, repo, wildcard, op)
+	if err != nil {
+		return giteeclient.BranchProtectionRule{}, err
+	}
+
+	if err := checkRule(rule, op); err != nil {
+		return giteeclient.BranchProtectionRule{}, err
+	}
+
+	if rule.Wildcard != wildcard {
+		if _, ok := r.rules[rule.Wildcard]; ok {
+			return giteeclient.BranchProtectionRule{}, newError(op, http.StatusUnprocessableEntity, "rule already exists")
+		}
+		delete(r.rules, wildcard)
+	}
+
+	v := rule
+	v.ID = old.ID
+	r.rules[v.Wildcard] = &v
+
+	c.record("UpdateBranchProtectionRule", org, repo, wildcard, rule)
+	return v, nil
+}
+
+func (c *Client) DeleteBranchProtectionRule(ctx context.Context, org, repo, wildcard string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, _, err := c.getRule(ctx, org, repo, wildcard, "delete branch protection rule")
+	if err != nil {
+		return err
+	}
+
+	delete(r.rules, wildcard)
+
+	c.record("DeleteBranchProtectionRule", org, repo, wildcard)
+	return nil
+}
+
+// checkRule checks the fields which Gitee requires to create or update a rule.
+func checkRule(rule giteeclient.BranchProtectionRule, op string) error {
+	if rule.Wildcard == "" {
+		return newError(op, http.StatusBadRequest, "wildcard is missing")
+	}
+	if _, err := path.Match(rule.Wildcard, ""); err != nil {
+		return newError(op, http.StatusBadRequest, "invalid wildcard")
+	}
+	if len(rule.Pushers) == 0 || len(rule.Mergers) == 0 {
+		return newError(op, http.StatusBadRequest, "pusher and merger are required")
+	}
+	if rule.ReviewCount < 0 {
+		return newError(op, http.StatusBadRequest, "invalid review_count")
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+// Package fake implements an in-memory giteeclient.ContextClient for the tests of plugins.
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+var _ giteeclient.ContextClient = (*Client)(nil)
+
+// Action records a mutating call which succeeded.
+type Action struct {
+	// Method is the name of the method called, such as "CreatePRComment".
+	Method string
+
+	// Args are the arguments of the call except the context.
+	Args []interface{}
+}
+
+func (a Action) String() string {
+	return fmt.Sprintf("%s%v", a.Method, a.Args)
+}
+
+// Client is an in-memory implementation of giteeclient.ContextClient.
+// It behaves like Gitee as much as possible, for example, it returns the
+// not found error for the unknown objects. Use giteeclient.WrapContextClient
+// to get a giteeclient.Client.
+type Client struct {
+	mut sync.Mutex
+
+	bot         sdk.User
+	users       map[string]sdk.User // lower case login -> user
+	repos       map[string]*repository
+	orgMembers  map[string]map[string]string // org -> login -> role
+	enterprises map[string]*enterprise
+	lastID      int32
+	actions     []Action
+}
+
+// NewClient creates a fake client whose bot is named "bot".
+func NewClient() *Client {
+	return &Client{
+		bot:         sdk.User{Id: 1, Login: "bot", Name: "bot"},
+		users:       map[string]sdk.User{},
+		repos:       map[string]*repository{},
+		orgMembers:  map[string]map[string]string{},
+		enterprises: map[string]*enterprise{},
+		lastID:      1,
+	}
+}
+
+// SetBot sets the user returned by GetBot and used as the author of comments.
+func (c *Client) SetBot(u sdk.User) {
+	c.mut.Lock()
+	c.bot = u
+	c.mut.Unlock()
+}
+
+// Actions returns the mutating calls which succeeded in the order they were made.
+func (c *Client) Actions() []Action {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r := make([]Action, len(c.actions))
+	copy(r, c.actions)
+	return r
+}
+
+// ActionsOf returns the mutating calls of method.
+func (c *Client) ActionsOf(method string) []Action {
+	var r []Action
+	for _, a := range c.Actions() {
+		if a.Method == method {
+			r = append(r, a)
+		}
+	}
+	return r
+}
+
+// ClearActions clears the recorded calls.
+func (c *Client) ClearActions() {
+	c.mut.Lock()
+	c.actions = nil
+	c.mut.Unlock()
+}
+
+// AddOrgMember makes login a member of org.
+func (c *Client) AddOrgMember(org, login string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	c.setOrgMember(org, login, giteeclient.OrgRoleMember)
+}
+
+func (c *Client) record(method string, args ...interface{}) {
+	c.actions = append(c.actions, Action{Method: method, Args: args})
+}
+
+func (c *Client) nextID() int32 {
+	c.lastID++
+	return c.lastID
+}
+
+func (c *Client) botBasic() *sdk.UserBasic {
+	return &sdk.UserBasic{Id: c.bot.Id, Login: c.bot.Login, Name: c.bot.Name}
+}
+
+func (c *Client) getRepo(ctx context.Context, org, repo, op string) (*repository, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if r, ok := c.repos[repoKey(org, repo)]; ok {
+		return r, nil
+	}
+	return nil, notFound(op, "Not Found Project")
+}
+
+func repoKey(org, repo string) string {
+	return org + "/" + repo
+}
+
+func notFound(op, msg string) error {
+	return giteeclient.NewError(op, http.StatusNotFound, msg)
+}
+
+func newError(op string, statusCode int, msg string) error {
+	return giteeclient.NewError(op, statusCode, msg)
+}
+
+func now() string {
+	return time.Now().Format(time.RFC3339)
+}
+
+func splitLabels(s string) []string {
+	var r []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+// iterate hands over the items one by one like the pagination of giteeclient.
+func iterate(n int, handle func(int) error) error {
+	for i := 0; i < n; i++ {
+		if err := handle(i); err != nil {
+			if errors.Is(err, giteeclient.ErrStopPagination) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func issueNumber(id int32) string {
+	return "I" + strings.ToUpper(strconv.FormatInt(int64(id), 36))
+}
@@ -0,0 +1,122 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestUnknownObjectsAreNotFound(t *testing.T) {
+	c := NewClient()
+	c.AddRepo("org", "repo")
+	ctx := context.Background()
+
+	if _, err := c.GetGiteeRepo(ctx, "org", "unknown"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found for unknown repo, got: %v", err)
+	}
+
+	if _, err := c.GetGiteePullRequest(ctx, "org", "repo", 1); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found for unknown pr, got: %v", err)
+	}
+
+	if err := c.UpdatePRComment(ctx, "org", "repo", 100, "x"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found for unknown comment, got: %v", err)
+	}
+
+	if _, err := c.GetPathContent(ctx, "org", "repo", "OWNERS", ""); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found for unknown file, got: %v", err)
+	}
+
+	if len(c.Actions()) != 0 {
+		t.Errorf("failed calls should not be recorded, got: %v", c.Actions())
+	}
+}
+
+func TestLabels(t *testing.T) {
+	c := NewClient()
+	number := c.AddPullRequest("org", "repo", sdk.PullRequest{})
+	issue := c.AddIssue("org", "repo", sdk.Issue{})
+	ctx := context.Background()
+
+	if err := c.AddMultiPRLabel(ctx, "org", "repo", number, []string{"lgtm", "approved", "lgtm"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.RemovePRLabel(ctx, "org", "repo", number, "missing"); err != nil {
+		t.Errorf("removing a missing label of pr should succeed, got: %v", err)
+	}
+
+	labels, _ := c.GetPRLabels(ctx, "org", "repo", number)
+	if n := labelNames(labels); n.Len() != 2 || len(labels) != 2 || !n.HasAll("lgtm", "approved") {
+		t.Errorf("unexpected labels of pr: %v", labels)
+	}
+
+	repoLabels, _ := c.GetRepoLabels(ctx, "org", "repo")
+	if len(repoLabels) != 2 {
+		t.Errorf("labels should be created in the repo, got: %v", repoLabels)
+	}
+
+	if err := c.RemoveIssueLabel(ctx, "org", "repo", issue, "missing"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found when removing a missing label of issue, got: %v", err)
+	}
+
+	if err := c.CreateRepoLabel(ctx, "org", "repo", "lgtm", "ffffff"); !giteeclient.IsUnprocessable(err) {
+		t.Errorf("expected unprocessable when creating an existing label, got: %v", err)
+	}
+}
+
+func TestCommentsAndActions(t *testing.T) {
+	c := NewClient()
+	number := c.AddPullRequest("org", "repo", sdk.PullRequest{})
+	ctx := context.Background()
+
+	for _, body := range []string{"first", "second"} {
+		if err := c.CreatePRComment(ctx, "org", "repo", number, body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	comments, _ := c.ListPRComments(ctx, "org", "repo", number)
+	if len(comments) != 2 || comments[0].Id == comments[1].Id {
+		t.Fatalf("comments should have distinct ids, got: %v", comments)
+	}
+
+	if comments[0].User == nil || comments[0].User.Login != "bot" {
+		t.Errorf("comment should be created by the bot, got: %v", comments[0].User)
+	}
+
+	if err := c.DeletePRComment(ctx, "org", "repo", comments[0].Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.MergePR(ctx, "org", "repo", number, sdk.PullRequestMergePutParam{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.MergePR(ctx, "org", "repo", number, sdk.PullRequestMergePutParam{}); !giteeclient.IsConflict(err) {
+		t.Errorf("expected conflict when merging a merged pr, got: %v", err)
+	}
+
+	var methods []string
+	for _, a := range c.Actions() {
+		methods = append(methods, a.Method)
+	}
+
+	expected := []string{"CreatePRComment", "CreatePRComment", "DeletePRComment", "MergePR"}
+	if len(methods) != len(expected) {
+		t.Fatalf("expected actions %v, got: %v", expected, methods)
+	}
+	for i := range expected {
+		if methods[i] != expected[i] {
+			t.Errorf("expected actions %v, got: %v", expected, methods)
+			break
+		}
+	}
+
+	if n := len(c.ActionsOf("CreatePRComment")); n != 2 {
+		t.Errorf("expected 2 comment actions, got: %d", n)
+	}
+}
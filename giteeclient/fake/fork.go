@@ -0,0 +1,128 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
+
+// fork copies the project, branches, files and commits of r into namespace.
+// Unlike Gitee, the fork is ready as soon as it is created.
+func (c *Client) fork(r *repository, namespace string) *repository {
+	parent := r.project
+
+	f := c.addProject(namespace, sdk.Project{
+		Path:          parent.Path,
+		Name:          parent.Name,
+		Description:   parent.Description,
+		DefaultBranch: parent.DefaultBranch,
+		Fork:          true,
+		Parent:        &parent,
+		CreatedAt:     now(),
+	})
+
+	f.branches = map[string]*branch{}
+	f.files = map[string]map[string]*file{}
+	for name, b := range r.branches {
+		f.branches[name] = &branch{name: name, sha: b.sha}
+
+		files := map[string]*file{}
+		for k, v := range r.files[name] {
+			v := *v
+			files[k] = &v
+		}
+		f.files[name] = files
+	}
+
+	for sha, v := range r.commits {
+		f.commits[sha] = v
+	}
+	return f
+}
+
+func (c *Client) forksOf(org, repo string) []sdk.Project {
+	key := repoKey(org, repo)
+
+	var v []sdk.Project
+	for _, r := range c.repos {
+		if p := r.project.Parent; p != nil && p.FullName == key {
+			v = append(v, r.project)
+		}
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Id < v[j].Id
+	})
+	return v
+}
+
+// ForkRepo forks into the namespace only if the bot is a member of it.
+func (c *Client) ForkRepo(ctx context.Context, org, repo, namespace string) (sdk.Project, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "fork repo"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return sdk.Project{}, err
+	}
+
+	if namespace == "" {
+		namespace = c.bot.Login
+	} else if _, ok := c.orgMembers[namespace][c.bot.Login]; !ok {
+		return sdk.Project{}, newError(op, http.StatusForbidden, "not a member of the organization")
+	}
+
+	if _, ok := c.repos[repoKey(namespace, repo)]; ok {
+		return sdk.Project{}, newError(op, http.StatusUnprocessableEntity, "repository already exists")
+	}
+
+	v := c.fork(r, namespace).project
+
+	c.record("ForkRepo", org, repo, namespace)
+	return v, nil
+}
+
+func (c *Client) ListForks(ctx context.Context, org, repo string) ([]sdk.Project, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if _, err := c.getRepo(ctx, org, repo, "list forks"); err != nil {
+		return nil, err
+	}
+	return c.forksOf(org, repo), nil
+}
+
+func (c *Client) GetBotFork(ctx context.Context, org, repo string) (sdk.Project, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "get bot fork"
+
+	if _, err := c.getRepo(ctx, org, repo, op); err != nil {
+		return sdk.Project{}, err
+	}
+
+	for _, p := range c.forksOf(org, repo) {
+		if strings.EqualFold(p.Namespace.Path, c.bot.Login) {
+			return p, nil
+		}
+	}
+	return sdk.Project{}, notFound(op, "Not Found Fork")
+}
+
+// WaitForFork returns at once, since the fork of fake is ready when it is created.
+func (c *Client) WaitForFork(ctx context.Context, org, repo string) (sdk.Project, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "get repo")
+	if err != nil {
+		return sdk.Project{}, err
+	}
+	return r.project, nil
+}
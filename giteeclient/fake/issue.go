@@ -0,0 +1,445 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+type issue struct {
+	issue    sdk.Issue
+	comments []sdk.Note
+}
+
+// AddIssue adds an issue to org/repo and returns its number. The number is
+// assigned if issue.Number is empty, and the state is open if it is empty.
+// The repository is added if it does not exist.
+func (c *Client) AddIssue(org, repo string, issue sdk.Issue) string {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r := c.ensureRepo(org, repo)
+	return c.addIssue(r, issue).Number
+}
+
+func (c *Client) addIssue(r *repository, v sdk.Issue) sdk.Issue {
+	if v.Id == 0 {
+		v.Id = c.nextID()
+	}
+	if v.Number == "" {
+		v.Number = issueNumber(v.Id)
+	}
+	if v.State == "" {
+		v.State = giteeclient.StatusOpen
+	}
+	if v.CreatedAt == "" {
+		v.CreatedAt = now()
+	}
+	if v.User == nil {
+		v.User = c.botBasic()
+	}
+	v.HtmlUrl = r.project.HtmlUrl + "/issues/" + v.Number
+	v.Repository = &r.project
+
+	for i := range v.Labels {
+		v.Labels[i] = c.label(r, v.Labels[i].Name)
+	}
+
+	r.issues[v.Number] = &issue{issue: v}
+	return v
+}
+
+func (c *Client) getIssue(ctx context.Context, org, repo, number, op string) (*repository, *issue, error) {
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	i, ok := r.issues[number]
+	if !ok {
+		return nil, nil, notFound(op, "Not Found Issue")
+	}
+	return r, i, nil
+}
+
+func (c *Client) GetIssue(ctx context.Context, org, repo, number string) (sdk.Issue, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, i, err := c.getIssue(ctx, org, repo, number, "get issue")
+	if err != nil {
+		return sdk.Issue{}, err
+	}
+	return i.issue, nil
+}
+
+func (c *Client) CreateIssue(ctx context.Context, org, repo, title string, opts giteeclient.CreateIssueOpt) (sdk.Issue, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "create issue"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return sdk.Issue{}, err
+	}
+
+	if title == "" {
+		return sdk.Issue{}, newError(op, http.StatusBadRequest, "title is missing")
+	}
+
+	v := sdk.Issue{
+		Title:     title,
+		Body:      opts.Body,
+		IssueType: opts.IssueType,
+		Labels:    c.labels(r, opts.Labels),
+	}
+
+	if a := opts.Assignee; a != "" {
+		if _, ok := r.collaborators[a]; !ok {
+			return sdk.Issue{}, newError(op, http.StatusForbidden, "the assignee is not a member of the repository")
+		}
+		v.Assignee = &sdk.UserBasic{Login: a, Name: a}
+	}
+
+	if n := opts.MilestoneNumber; n > 0 {
+		if _, ok := r.milestones[n]; !ok {
+			return sdk.Issue{}, notFound(op, "Not Found Milestone")
+		}
+		v.Milestone = r.milestone(n)
+	}
+
+	v = c.addIssue(r, v)
+
+	c.record("CreateIssue", org, repo, title, opts)
+	return v, nil
+}
+
+// ListIssues supports the special assignees of Gitee, "none" for the issues
+// without assignee and "*" for the ones with any assignee.
+func (c *Client) ListIssues(ctx context.Context, org, repo string, opts giteeclient.ListIssueOpt) ([]sdk.Issue, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "list issues")
+	if err != nil {
+		return nil, err
+	}
+
+	state := opts.State
+	if state == "" {
+		state = giteeclient.StatusOpen
+	}
+
+	var v []sdk.Issue
+	for _, item := range r.issues {
+		i := item.issue
+
+		if state != stateAll && i.State != state {
+			continue
+		}
+		if len(opts.Labels) > 0 && !labelNames(i.Labels).HasAll(opts.Labels...) {
+			continue
+		}
+		if opts.Creator != "" && (i.User == nil || i.User.Login != opts.Creator) {
+			continue
+		}
+		if !matchAssignee(i.Assignee, opts.Assignee) {
+			continue
+		}
+		if !opts.Since.IsZero() && issueUpdatedAt(i).Before(opts.Since) {
+			continue
+		}
+
+		v = append(v, i)
+	}
+
+	key := func(i sdk.Issue) string {
+		if opts.Sort == "updated" && i.UpdatedAt != "" {
+			return i.UpdatedAt
+		}
+		return i.CreatedAt
+	}
+	asc := opts.Direction == "asc"
+	sort.Slice(v, func(i, j int) bool {
+		ki, kj := key(v[i]), key(v[j])
+		if ki == kj {
+			return (v[i].Id < v[j].Id) == asc
+		}
+		return (ki < kj) == asc
+	})
+	return v, nil
+}
+
+func matchAssignee(assignee *sdk.UserBasic, want string) bool {
+	switch want {
+	case "":
+		return true
+	case "none":
+		return assignee == nil
+	case "*":
+		return assignee != nil
+	}
+	return assignee != nil && assignee.Login == want
+}
+
+func issueUpdatedAt(i sdk.Issue) time.Time {
+	s := i.UpdatedAt
+	if s == "" {
+		s = i.CreatedAt
+	}
+
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+func (c *Client) UpdateIssue(ctx context.Context, owner, number string, param sdk.IssueUpdateParam) (sdk.Issue, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	v, err := c.updateIssue(ctx, owner, number, param, "update issue")
+	if err != nil {
+		return v, err
+	}
+
+	c.record("UpdateIssue", owner, number, param)
+	return v, nil
+}
+
+func (c *Client) updateIssue(ctx context.Context, owner, number string, param sdk.IssueUpdateParam, op string) (sdk.Issue, error) {
+	r, i, err := c.getIssue(ctx, owner, param.Repo, number, op)
+	if err != nil {
+		return sdk.Issue{}, err
+	}
+
+	switch param.State {
+	case "":
+	case giteeclient.StatusOpen, giteeclient.StatusClosed, "progressing", "rejected":
+		i.issue.State = param.State
+		if param.State == giteeclient.StatusClosed {
+			i.issue.FinishedAt = now()
+		}
+	default:
+		return sdk.Issue{}, newError(op, http.StatusBadRequest, "invalid state")
+	}
+
+	// Gitee unassigns the issue when the assignee is a blank.
+	switch a := strings.TrimSpace(param.Assignee); {
+	case param.Assignee == "":
+	case a == "":
+		i.issue.Assignee = nil
+	default:
+		if _, ok := r.collaborators[a]; !ok {
+			return sdk.Issue{}, newError(op, http.StatusForbidden, "the assignee is not a member of the repository")
+		}
+		i.issue.Assignee = &sdk.UserBasic{Login: a, Name: a}
+	}
+
+	if param.Title != "" {
+		i.issue.Title = param.Title
+	}
+	if param.Body != "" {
+		i.issue.Body = param.Body
+	}
+	if param.Labels != "" {
+		i.issue.Labels = c.labels(r, splitLabels(param.Labels))
+	}
+	if param.Milestone > 0 {
+		i.issue.Milestone = r.milestone(param.Milestone)
+	}
+	i.issue.UpdatedAt = now()
+
+	return i.issue, nil
+}
+
+func (c *Client) AssignGiteeIssue(ctx context.Context, org, repo string, number string, login string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	opt := sdk.IssueUpdateParam{Repo: repo, Assignee: login}
+	if _, err := c.updateIssue(ctx, org, number, opt, "assign assignee to issue"); err != nil {
+		return err
+	}
+
+	c.record("AssignGiteeIssue", org, repo, number, login)
+	return nil
+}
+
+func (c *Client) UnassignGiteeIssue(ctx context.Context, org, repo string, number string, login string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	opt := sdk.IssueUpdateParam{Repo: repo, Assignee: " "}
+	if _, err := c.updateIssue(ctx, org, number, opt, "assign assignee to issue"); err != nil {
+		return err
+	}
+
+	c.record("UnassignGiteeIssue", org, repo, number, login)
+	return nil
+}
+
+func (c *Client) CloseIssue(ctx context.Context, owner, repo string, number string) error {
+	return c.setIssueState(ctx, owner, repo, number, giteeclient.StatusClosed, "CloseIssue")
+}
+
+func (c *Client) ReopenIssue(ctx context.Context, owner, repo string, number string) error {
+	return c.setIssueState(ctx, owner, repo, number, giteeclient.StatusOpen, "ReopenIssue")
+}
+
+func (c *Client) setIssueState(ctx context.Context, owner, repo, number, state, method string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	opt := sdk.IssueUpdateParam{Repo: repo, State: state}
+	if _, err := c.updateIssue(ctx, owner, number, opt, "update issue"); err != nil {
+		return err
+	}
+
+	c.record(method, owner, repo, number)
+	return nil
+}
+
+func (c *Client) ListIssueComments(ctx context.Context, org, repo, number string) ([]sdk.Note, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, i, err := c.getIssue(ctx, org, repo, number, "list comments of issue")
+	if err != nil {
+		return nil, err
+	}
+	return append([]sdk.Note(nil), i.comments...), nil
+}
+
+func (c *Client) IterateIssueComments(ctx context.Context, org, repo, number string, handle func(sdk.Note) error) error {
+	v, err := c.ListIssueComments(ctx, org, repo, number)
+	if err != nil {
+		return err
+	}
+
+	return iterate(len(v), func(i int) error {
+		return handle(v[i])
+	})
+}
+
+func (c *Client) CreateIssueComment(ctx context.Context, org, repo string, number string, comment string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, i, err := c.getIssue(ctx, org, repo, number, "create issue comment")
+	if err != nil {
+		return err
+	}
+
+	t := now()
+	i.comments = append(i.comments, sdk.Note{
+		Id:        c.nextID(),
+		Body:      comment,
+		User:      c.botBasic(),
+		CreatedAt: t,
+		UpdatedAt: t,
+		Target: &sdk.NoteTarget{
+			Issue: &sdk.IssueBasic{Id: i.issue.Id, Number: i.issue.Number, Title: i.issue.Title},
+		},
+	})
+	i.issue.Comments++
+
+	c.record("CreateIssueComment", org, repo, number, comment)
+	return nil
+}
+
+func (c *Client) UpdateIssueComment(ctx context.Context, org, repo string, commentID int32, comment string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "update comment of issue"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range r.issues {
+		for j := range i.comments {
+			if i.comments[j].Id == commentID {
+				i.comments[j].Body = comment
+				i.comments[j].UpdatedAt = now()
+
+				c.record("UpdateIssueComment", org, repo, commentID, comment)
+				return nil
+			}
+		}
+	}
+	return notFound(op, "Not Found Comment")
+}
+
+func (c *Client) GetIssueLabels(ctx context.Context, org, repo, number string) ([]sdk.Label, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, i, err := c.getIssue(ctx, org, repo, number, "get issue labels")
+	if err != nil {
+		return nil, err
+	}
+	return append([]sdk.Label(nil), i.issue.Labels...), nil
+}
+
+func (c *Client) AddIssueLabel(ctx context.Context, org, repo, number, label string) error {
+	return c.addIssueLabels(ctx, org, repo, number, []string{label}, "AddIssueLabel")
+}
+
+func (c *Client) AddMultiIssueLabel(ctx context.Context, org, repo, number string, label []string) error {
+	return c.addIssueLabels(ctx, org, repo, number, label, "AddMultiIssueLabel")
+}
+
+func (c *Client) addIssueLabels(ctx context.Context, org, repo, number string, label []string, method string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, i, err := c.getIssue(ctx, org, repo, number, "add issue label")
+	if err != nil {
+		return err
+	}
+
+	i.issue.Labels = c.addLabels(r, i.issue.Labels, label)
+
+	c.record(method, org, repo, number, label)
+	return nil
+}
+
+func (c *Client) RemoveIssueLabel(ctx context.Context, org, repo, number, label string) error {
+	return c.removeIssueLabels(ctx, org, repo, number, splitLabels(label), "RemoveIssueLabel", label)
+}
+
+func (c *Client) RemoveIssueLabels(ctx context.Context, org, repo, number string, label []string) error {
+	return c.removeIssueLabels(ctx, org, repo, number, label, "RemoveIssueLabels", label)
+}
+
+// removeIssueLabels returns the not found error if the issue does not have
+// one of the labels, which is different from removing the labels of pr.
+func (c *Client) removeIssueLabels(ctx context.Context, org, repo, number string, labels []string, method string, arg interface{}) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "rm issue label"
+
+	_, i, err := c.getIssue(ctx, org, repo, number, op)
+	if err != nil {
+		return err
+	}
+
+	if !labelNames(i.issue.Labels).HasAll(labels...) {
+		return notFound(op, "Not Found Label")
+	}
+
+	i.issue.Labels = removeLabels(i.issue.Labels, sets.NewString(labels...))
+
+	c.record(method, org, repo, number, arg)
+	return nil
+}
@@ -0,0 +1,159 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+type enterprise struct {
+	members []giteeclient.EnterpriseMember
+}
+
+// AddEnterpriseMember adds a member to the enterprise.
+// The enterprise is added if it does not exist.
+func (c *Client) AddEnterpriseMember(name string, m giteeclient.EnterpriseMember) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	e := c.ensureEnterprise(name)
+	e.members = append(e.members, m)
+}
+
+func (c *Client) ensureEnterprise(name string) *enterprise {
+	e, ok := c.enterprises[name]
+	if !ok {
+		e = &enterprise{}
+		c.enterprises[name] = e
+	}
+	return e
+}
+
+func (c *Client) getEnterprise(ctx context.Context, name, op string) (*enterprise, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	e, ok := c.enterprises[name]
+	if !ok {
+		return nil, notFound(op, "Not Found Enterprise")
+	}
+	return e, nil
+}
+
+func (c *Client) setOrgMember(org, login, role string) {
+	if c.orgMembers[org] == nil {
+		c.orgMembers[org] = map[string]string{}
+	}
+	c.orgMembers[org][login] = role
+}
+
+func (c *Client) ListOrgMembers(ctx context.Context, org, role string) ([]giteeclient.OrgMember, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	var v []giteeclient.OrgMember
+	for login, r := range c.orgMembers[org] {
+		if role == "" || role == stateAll || role == r {
+			v = append(v, giteeclient.OrgMember{Login: login, Name: login, Role: r})
+		}
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Login < v[j].Login
+	})
+	return v, nil
+}
+
+func (c *Client) SetOrgMember(ctx context.Context, org, login, role string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	switch role {
+	case giteeclient.OrgRoleAdmin, giteeclient.OrgRoleMember:
+	default:
+		return newError("set org member", http.StatusBadRequest, "invalid role")
+	}
+
+	c.setOrgMember(org, login, role)
+
+	c.record("SetOrgMember", org, login, role)
+	return nil
+}
+
+func (c *Client) RemoveOrgMember(ctx context.Context, org, login string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if _, ok := c.orgMembers[org][login]; !ok {
+		return notFound("remove org member", "Not Found User")
+	}
+
+	delete(c.orgMembers[org], login)
+
+	c.record("RemoveOrgMember", org, login)
+	return nil
+}
+
+func (c *Client) listOrgs(login string) []sdk.Group {
+	var v []sdk.Group
+	for org, members := range c.orgMembers {
+		if _, ok := members[login]; ok {
+			v = append(v, sdk.Group{Login: org, Name: org})
+		}
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Login < v[j].Login
+	})
+	return v
+}
+
+func (c *Client) ListUserOrgs(ctx context.Context, login string) ([]sdk.Group, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.listOrgs(login), nil
+}
+
+func (c *Client) ListBotOrgs(ctx context.Context) ([]sdk.Group, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.listOrgs(c.bot.Login), nil
+}
+
+func (c *Client) ListEnterpriseMembers(ctx context.Context, name string) ([]giteeclient.EnterpriseMember, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	e, err := c.getEnterprise(ctx, name, "list enterprise members")
+	if err != nil {
+		return nil, err
+	}
+	return append([]giteeclient.EnterpriseMember(nil), e.members...), nil
+}
@@ -0,0 +1,277 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+const stateAll = "all"
+
+// AddMilestone adds a milestone to org/repo and returns its number. The number
+// is assigned if m.Number is empty, and the state is open if it is empty.
+// The repository is added if it does not exist.
+func (c *Client) AddMilestone(org, repo string, m sdk.Milestone) int32 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.addMilestone(c.ensureRepo(org, repo), m).Number
+}
+
+func (c *Client) addMilestone(r *repository, m sdk.Milestone) sdk.Milestone {
+	if m.Id == 0 {
+		m.Id = c.nextID()
+	}
+	if m.Number == 0 {
+		m.Number = r.lastMilestone + 1
+	}
+	if m.Number > r.lastMilestone {
+		r.lastMilestone = m.Number
+	}
+	if m.State == "" {
+		m.State = giteeclient.StatusOpen
+	}
+	if m.CreatedAt == "" {
+		m.CreatedAt = now()
+	}
+	m.RepositoryId = r.project.Id
+	m.HtmlUrl = r.project.HtmlUrl + "/milestones/" + fmt.Sprint(m.Number)
+
+	r.milestones[m.Number] = &m
+	return m
+}
+
+// milestone returns a copy of the milestone. A milestone with the number only
+// is returned if it was not added, so that the tests which don't care about
+// milestones needn't add them.
+func (r *repository) milestone(number int32) *sdk.Milestone {
+	if m, ok := r.milestones[number]; ok {
+		v := *m
+		return &v
+	}
+	return &sdk.Milestone{Number: number}
+}
+
+func (c *Client) getMilestone(ctx context.Context, org, repo string, number int32, op string) (*repository, *sdk.Milestone, error) {
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m, ok := r.milestones[number]
+	if !ok {
+		return nil, nil, notFound(op, "Not Found Milestone")
+	}
+	return r, m, nil
+}
+
+func (c *Client) ListMilestones(ctx context.Context, org, repo, state string) ([]sdk.Milestone, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "list milestones")
+	if err != nil {
+		return nil, err
+	}
+
+	if state == "" {
+		state = giteeclient.StatusOpen
+	}
+
+	var v []sdk.Milestone
+	for _, m := range r.milestones {
+		if state == stateAll || m.State == state {
+			v = append(v, *m)
+		}
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Number < v[j].Number
+	})
+	return v, nil
+}
+
+func (c *Client) GetMilestone(ctx context.Context, org, repo string, number int32) (sdk.Milestone, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, m, err := c.getMilestone(ctx, org, repo, number, "get milestone")
+	if err != nil {
+		return sdk.Milestone{}, err
+	}
+	return *m, nil
+}
+
+func (c *Client) CreateMilestone(ctx context.Context, org, repo string, param sdk.MilestonePostParam) (sdk.Milestone, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "create milestone"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return sdk.Milestone{}, err
+	}
+
+	if err := checkMilestone(param.Title, param.DueOn, param.State, op); err != nil {
+		return sdk.Milestone{}, err
+	}
+
+	for _, m := range r.milestones {
+		if m.Title == param.Title {
+			return sdk.Milestone{}, newError(op, http.StatusUnprocessableEntity, "milestone already exists")
+		}
+	}
+
+	m := c.addMilestone(r, sdk.Milestone{
+		Title:       param.Title,
+		State:       param.State,
+		Description: param.Description,
+		DueOn:       param.DueOn,
+	})
+
+	c.record("CreateMilestone", org, repo, param)
+	return m, nil
+}
+
+func (c *Client) UpdateMilestone(ctx context.Context, org, repo string, number int32, param sdk.MilestonePatchParam) (sdk.Milestone, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "update milestone"
+
+	_, m, err := c.getMilestone(ctx, org, repo, number, op)
+	if err != nil {
+		return sdk.Milestone{}, err
+	}
+
+	if err := checkMilestone(param.Title, param.DueOn, param.State, op); err != nil {
+		return sdk.Milestone{}, err
+	}
+
+	m.Title = param.Title
+	m.DueOn = param.DueOn
+	if param.State != "" {
+		m.State = param.State
+	}
+	if param.Description != "" {
+		m.Description = param.Description
+	}
+	m.UpdatedAt = now()
+
+	c.record("UpdateMilestone", org, repo, number, param)
+	return *m, nil
+}
+
+func (c *Client) CloseMilestone(ctx context.Context, org, repo string, number int32) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, m, err := c.getMilestone(ctx, org, repo, number, "close milestone")
+	if err != nil {
+		return err
+	}
+
+	m.State = giteeclient.StatusClosed
+	m.UpdatedAt = now()
+
+	c.record("CloseMilestone", org, repo, number)
+	return nil
+}
+
+func (c *Client) SetIssueMilestone(ctx context.Context, org, repo, number string, milestone int32) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "set milestone of issue"
+
+	r, i, err := c.getIssue(ctx, org, repo, number, op)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.milestones[milestone]; !ok {
+		return notFound(op, "Not Found Milestone")
+	}
+
+	i.issue.Milestone = r.milestone(milestone)
+	i.issue.UpdatedAt = now()
+
+	c.record("SetIssueMilestone", org, repo, number, milestone)
+	return nil
+}
+
+func (c *Client) ClearIssueMilestone(ctx context.Context, org, repo, number string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, i, err := c.getIssue(ctx, org, repo, number, "clear milestone of issue")
+	if err != nil {
+		return err
+	}
+
+	i.issue.Milestone = nil
+	i.issue.UpdatedAt = now()
+
+	c.record("ClearIssueMilestone", org, repo, number)
+	return nil
+}
+
+func (c *Client) SetPRMilestone(ctx context.Context, org, repo string, number, milestone int32) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "set milestone of pr"
+
+	r, pr, err := c.getPR(ctx, org, repo, number, op)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.milestones[milestone]; !ok {
+		return notFound(op, "Not Found Milestone")
+	}
+
+	pr.pr.Milestone = r.milestone(milestone)
+	pr.pr.UpdatedAt = now()
+
+	c.record("SetPRMilestone", org, repo, number, milestone)
+	return nil
+}
+
+func (c *Client) ClearPRMilestone(ctx context.Context, org, repo string, number int32) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "clear milestone of pr")
+	if err != nil {
+		return err
+	}
+
+	pr.pr.Milestone = nil
+	pr.pr.UpdatedAt = now()
+
+	c.record("ClearPRMilestone", org, repo, number)
+	return nil
+}
+
+// checkMilestone checks the fields which Gitee requires to create or update a milestone.
+func checkMilestone(title, dueOn, state, op string) error {
+	if title == "" {
+		return newError(op, http.StatusBadRequest, "title is missing")
+	}
+	if dueOn == "" {
+		return newError(op, http.StatusBadRequest, "due_on is missing")
+	}
+
+	switch state {
+	case "", giteeclient.StatusOpen, giteeclient.StatusClosed:
+		return nil
+	}
+	return newError(op, http.StatusBadRequest, "invalid state")
+}
@@ -0,0 +1,204 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+const permissionAdmin = "admin"
+
+func (c *Client) GetPRReviewState(ctx context.Context, org, repo string, number int32) (giteeclient.PRReviewState, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "get review state of pr")
+	if err != nil {
+		return giteeclient.PRReviewState{}, err
+	}
+
+	return giteeclient.PRReviewState{
+		Assignees:       reviewers(pr.pr.Assignees, pr.reviewed),
+		Testers:         reviewers(pr.pr.Testers, pr.tested),
+		AssigneesNumber: pr.pr.AssigneesNumber,
+		TestersNumber:   pr.pr.TestersNumber,
+	}, nil
+}
+
+func (c *Client) AssignPRTesters(ctx context.Context, org, repo string, number int32, logins []string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "assign tester to pr")
+	if err != nil {
+		return err
+	}
+
+	exists := sets.NewString()
+	for _, u := range pr.pr.Testers {
+		exists.Insert(u.Login)
+	}
+
+	for _, login := range logins {
+		if !exists.Has(login) {
+			exists.Insert(login)
+			pr.pr.Testers = append(pr.pr.Testers, sdk.UserBasic{Login: login, Name: login})
+		}
+	}
+
+	c.record("AssignPRTesters", org, repo, number, logins)
+	return nil
+}
+
+func (c *Client) UnassignPRTesters(ctx context.Context, org, repo string, number int32, logins []string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "unassign tester from pr")
+	if err != nil {
+		return err
+	}
+
+	rm := sets.NewString(logins...)
+	var v []sdk.UserBasic
+	for _, u := range pr.pr.Testers {
+		if !rm.Has(u.Login) {
+			v = append(v, u)
+		}
+	}
+	pr.pr.Testers = v
+	pr.tested.Delete(logins...)
+
+	c.record("UnassignPRTesters", org, repo, number, logins)
+	return nil
+}
+
+// ApprovePRReview approves the review as the bot. All the reviewers are
+// regarded as approved if it is forced.
+func (c *Client) ApprovePRReview(ctx context.Context, org, repo string, number int32, force bool) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if err := c.accept(ctx, org, repo, number, force, true, "approve review of pr"); err != nil {
+		return err
+	}
+
+	c.record("ApprovePRReview", org, repo, number, force)
+	return nil
+}
+
+// PassPRTest passes the test as the bot. All the testers are regarded as
+// passed if it is forced.
+func (c *Client) PassPRTest(ctx context.Context, org, repo string, number int32, force bool) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if err := c.accept(ctx, org, repo, number, force, false, "pass test of pr"); err != nil {
+		return err
+	}
+
+	c.record("PassPRTest", org, repo, number, force)
+	return nil
+}
+
+func (c *Client) ResetPRReview(ctx context.Context, org, repo string, number int32, resetAll bool) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if err := c.reset(ctx, org, repo, number, resetAll, true, "reset review of pr"); err != nil {
+		return err
+	}
+
+	c.record("ResetPRReview", org, repo, number, resetAll)
+	return nil
+}
+
+func (c *Client) ResetPRTest(ctx context.Context, org, repo string, number int32, resetAll bool) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if err := c.reset(ctx, org, repo, number, resetAll, false, "reset test of pr"); err != nil {
+		return err
+	}
+
+	c.record("ResetPRTest", org, repo, number, resetAll)
+	return nil
+}
+
+func (c *Client) accept(ctx context.Context, org, repo string, number int32, force, review bool, op string) error {
+	r, pr, err := c.getPR(ctx, org, repo, number, op)
+	if err != nil {
+		return err
+	}
+
+	if pr.pr.State != giteeclient.StatusOpen {
+		return newError(op, http.StatusBadRequest, "the pull request is not open")
+	}
+
+	users, accepted := pr.pr.Testers, pr.tested
+	if review {
+		users, accepted = pr.pr.Assignees, pr.reviewed
+	}
+
+	if force {
+		if r.collaborators[c.bot.Login] != permissionAdmin {
+			return newError(op, http.StatusForbidden, "only the administrators can force it")
+		}
+
+		for _, u := range users {
+			accepted.Insert(u.Login)
+		}
+		return nil
+	}
+
+	for _, u := range users {
+		if u.Login == c.bot.Login {
+			accepted.Insert(u.Login)
+			return nil
+		}
+	}
+	return newError(op, http.StatusForbidden, "the bot is not assigned to the pull request")
+}
+
+func (c *Client) reset(ctx context.Context, org, repo string, number int32, resetAll, review bool, op string) error {
+	r, pr, err := c.getPR(ctx, org, repo, number, op)
+	if err != nil {
+		return err
+	}
+
+	accepted := pr.tested
+	if review {
+		accepted = pr.reviewed
+	}
+
+	if !resetAll {
+		accepted.Delete(c.bot.Login)
+		return nil
+	}
+
+	if r.collaborators[c.bot.Login] != permissionAdmin {
+		return newError(op, http.StatusForbidden, "only the administrators can reset all")
+	}
+
+	for _, login := range accepted.UnsortedList() {
+		accepted.Delete(login)
+	}
+	return nil
+}
+
+func reviewers(users []sdk.UserBasic, accepted sets.String) []giteeclient.PRReviewer {
+	v := make([]giteeclient.PRReviewer, len(users))
+	for i, u := range users {
+		v[i] = giteeclient.PRReviewer{
+			ID:     u.Id,
+			Login:  u.Login,
+			Name:   u.Name,
+			Accept: accepted.Has(u.Login),
+		}
+	}
+	return v
+}
@@ -0,0 +1,637 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+const stateMerged = "merged"
+
+type pullRequest struct {
+	pr       sdk.PullRequest
+	comments []sdk.PullRequestComments
+	files    []sdk.PullRequestFiles
+	commits  []sdk.PullRequestCommits
+	issues   []string
+	logs     []sdk.OperateLog
+	reviewed sets.String // the reviewers who have approved the review
+	tested   sets.String // the testers who have passed the test
+}
+
+// AddPullRequest adds a pull request to org/repo and returns its number.
+// The number is assigned if pr.Number is 0, and the state is open if it is empty.
+// The repository is added if it does not exist.
+func (c *Client) AddPullRequest(org, repo string, pr sdk.PullRequest) int32 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r := c.ensureRepo(org, repo)
+	return c.addPullRequest(r, pr).Number
+}
+
+// SetPRChanges sets the files changed by the pull request.
+func (c *Client) SetPRChanges(org, repo string, number int32, files []sdk.PullRequestFiles) {
+	c.mut.Lock()
+	c.mustGetPR(org, repo, number).files = files
+	c.mut.Unlock()
+}
+
+// SetPRCommits sets the commits of the pull request.
+func (c *Client) SetPRCommits(org, repo string, number int32, commits []sdk.PullRequestCommits) {
+	c.mut.Lock()
+	c.mustGetPR(org, repo, number).commits = commits
+	c.mut.Unlock()
+}
+
+// LinkIssue links the issue of the same repository to the pull request.
+func (c *Client) LinkIssue(org, repo string, number int32, issueNumber string) {
+	c.mut.Lock()
+	pr := c.mustGetPR(org, repo, number)
+	pr.issues = append(pr.issues, issueNumber)
+	c.mut.Unlock()
+}
+
+// AddPROperationLog adds an operation log to the pull request.
+func (c *Client) AddPROperationLog(org, repo string, number int32, log sdk.OperateLog) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	pr := c.mustGetPR(org, repo, number)
+	if log.Id == 0 {
+		log.Id = c.nextID()
+	}
+	if log.CreatedAt == "" {
+		log.CreatedAt = now()
+	}
+	pr.logs = append(pr.logs, log)
+}
+
+func (c *Client) addPullRequest(r *repository, pr sdk.PullRequest) sdk.PullRequest {
+	if pr.Number == 0 {
+		pr.Number = r.lastPRNumber + 1
+	}
+	if pr.Number > r.lastPRNumber {
+		r.lastPRNumber = pr.Number
+	}
+	if pr.Id == 0 {
+		pr.Id = c.nextID()
+	}
+	if pr.State == "" {
+		pr.State = giteeclient.StatusOpen
+	}
+	if pr.CreatedAt == "" {
+		pr.CreatedAt = now()
+	}
+	if pr.User == nil {
+		pr.User = c.botBasic()
+	}
+	if pr.Base == nil {
+		b := r.branches[r.project.DefaultBranch]
+		pr.Base = &sdk.BranchBasic{Ref: b.name, Label: b.name, Sha: b.sha}
+	}
+	if pr.Head == nil {
+		pr.Head = &sdk.BranchBasic{}
+	}
+	pr.HtmlUrl = fmt.Sprintf("%s/pulls/%d", r.project.HtmlUrl, pr.Number)
+
+	for i := range pr.Labels {
+		pr.Labels[i] = c.label(r, pr.Labels[i].Name)
+	}
+
+	r.prs[pr.Number] = &pullRequest{
+		pr:       pr,
+		reviewed: sets.NewString(),
+		tested:   sets.NewString(),
+	}
+	return pr
+}
+
+func (c *Client) mustGetPR(org, repo string, number int32) *pullRequest {
+	r, ok := c.repos[repoKey(org, repo)]
+	if ok {
+		if pr, ok := r.prs[number]; ok {
+			return pr
+		}
+	}
+	panic(fmt.Sprintf("pull request %s!%d does not exist", repoKey(org, repo), number))
+}
+
+func (c *Client) getPR(ctx context.Context, org, repo string, number int32, op string) (*repository, *pullRequest, error) {
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pr, ok := r.prs[number]
+	if !ok {
+		return nil, nil, notFound(op, "Not Found PullRequest")
+	}
+	return r, pr, nil
+}
+
+func (c *Client) CreatePullRequest(ctx context.Context, org, repo, title, body, head, base string, canModify bool) (sdk.PullRequest, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "create pull request"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return sdk.PullRequest{}, err
+	}
+
+	baseBranch, ok := r.branches[base]
+	if !ok {
+		return sdk.PullRequest{}, newError(op, http.StatusBadRequest, "base branch does not exist")
+	}
+
+	headSHA := ""
+	if !strings.Contains(head, ":") {
+		b, ok := r.branches[head]
+		if !ok {
+			return sdk.PullRequest{}, newError(op, http.StatusBadRequest, "head branch does not exist")
+		}
+		headSHA = b.sha
+	}
+
+	for _, v := range r.prs {
+		if v.pr.State == giteeclient.StatusOpen && v.pr.Head.Ref == head && v.pr.Base.Ref == base {
+			return sdk.PullRequest{}, newError(op, http.StatusBadRequest, "the pull request already exists")
+		}
+	}
+
+	pr := c.addPullRequest(r, sdk.PullRequest{
+		Title:     title,
+		Body:      body,
+		Mergeable: true,
+		Head:      &sdk.BranchBasic{Ref: head, Label: head, Sha: headSHA},
+		Base:      &sdk.BranchBasic{Ref: base, Label: base, Sha: baseBranch.sha},
+	})
+
+	c.record("CreatePullRequest", org, repo, title, body, head, base, canModify)
+	return pr, nil
+}
+
+func (c *Client) GetPullRequests(ctx context.Context, org, repo string, opts giteeclient.ListPullRequestOpt) ([]sdk.PullRequest, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "list pull requests")
+	if err != nil {
+		return nil, err
+	}
+
+	state := opts.State
+	if state == "" {
+		state = giteeclient.StatusOpen
+	}
+
+	var v []sdk.PullRequest
+	for _, item := range r.prs {
+		pr := item.pr
+
+		if state != "all" && pr.State != state {
+			continue
+		}
+		if opts.Head != "" && pr.Head.Ref != opts.Head {
+			continue
+		}
+		if opts.Base != "" && pr.Base.Ref != opts.Base {
+			continue
+		}
+		if opts.MilestoneNumber > 0 && (pr.Milestone == nil || pr.Milestone.Number != opts.MilestoneNumber) {
+			continue
+		}
+		if len(opts.Labels) > 0 && !labelNames(pr.Labels).HasAll(opts.Labels...) {
+			continue
+		}
+
+		v = append(v, pr)
+	}
+
+	asc := opts.Direction == "asc"
+	sort.Slice(v, func(i, j int) bool {
+		if asc {
+			return v[i].Number < v[j].Number
+		}
+		return v[i].Number > v[j].Number
+	})
+	return v, nil
+}
+
+func (c *Client) UpdatePullRequest(ctx context.Context, org, repo string, number int32, param sdk.PullRequestUpdateParam) (sdk.PullRequest, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "update pull request"
+
+	r, pr, err := c.getPR(ctx, org, repo, number, op)
+	if err != nil {
+		return sdk.PullRequest{}, err
+	}
+
+	if param.State != "" {
+		if pr.pr.State == stateMerged {
+			return sdk.PullRequest{}, newError(op, http.StatusBadRequest, "the pull request has been merged")
+		}
+
+		switch param.State {
+		case giteeclient.StatusOpen:
+			pr.pr.ClosedAt = ""
+		case giteeclient.StatusClosed:
+			pr.pr.ClosedAt = now()
+		default:
+			return sdk.PullRequest{}, newError(op, http.StatusBadRequest, "invalid state")
+		}
+		pr.pr.State = param.State
+	}
+
+	if param.Title != "" {
+		pr.pr.Title = param.Title
+	}
+	if param.Body != "" {
+		pr.pr.Body = param.Body
+	}
+	if param.Labels != "" {
+		pr.pr.Labels = c.labels(r, splitLabels(param.Labels))
+	}
+	if param.MilestoneNumber > 0 {
+		pr.pr.Milestone = r.milestone(param.MilestoneNumber)
+	}
+	pr.pr.UpdatedAt = now()
+
+	c.record("UpdatePullRequest", org, repo, number, param)
+	return pr.pr, nil
+}
+
+func (c *Client) GetGiteePullRequest(ctx context.Context, org, repo string, number int32) (sdk.PullRequest, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "get pull request")
+	if err != nil {
+		return sdk.PullRequest{}, err
+	}
+	return pr.pr, nil
+}
+
+func (c *Client) GetPullRequestChanges(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestFiles, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "list files of pr")
+	if err != nil {
+		return nil, err
+	}
+	return append([]sdk.PullRequestFiles(nil), pr.files...), nil
+}
+
+func (c *Client) GetPRCommits(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestCommits, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "get pr commits")
+	if err != nil {
+		return nil, err
+	}
+	return append([]sdk.PullRequestCommits(nil), pr.commits...), nil
+}
+
+func (c *Client) ListPROperationLogs(ctx context.Context, org, repo string, number int32) ([]sdk.OperateLog, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "list pr operation logs")
+	if err != nil {
+		return nil, err
+	}
+	return append([]sdk.OperateLog(nil), pr.logs...), nil
+}
+
+func (c *Client) ListPrIssues(ctx context.Context, org, repo string, number int32) ([]sdk.Issue, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, pr, err := c.getPR(ctx, org, repo, number, "list issues of pr")
+	if err != nil {
+		return nil, err
+	}
+
+	var v []sdk.Issue
+	for _, n := range pr.issues {
+		if i, ok := r.issues[n]; ok {
+			v = append(v, i.issue)
+		}
+	}
+	return v, nil
+}
+
+func (c *Client) GetPRLabels(ctx context.Context, org, repo string, number int32) ([]sdk.Label, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "list labels of pr")
+	if err != nil {
+		return nil, err
+	}
+	return append([]sdk.Label(nil), pr.pr.Labels...), nil
+}
+
+func (c *Client) IteratePRLabels(ctx context.Context, org, repo string, number int32, handle func(sdk.Label) error) error {
+	v, err := c.GetPRLabels(ctx, org, repo, number)
+	if err != nil {
+		return err
+	}
+
+	return iterate(len(v), func(i int) error {
+		return handle(v[i])
+	})
+}
+
+func (c *Client) ListPRComments(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestComments, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "list comments of pr")
+	if err != nil {
+		return nil, err
+	}
+	return append([]sdk.PullRequestComments(nil), pr.comments...), nil
+}
+
+func (c *Client) IteratePRComments(ctx context.Context, org, repo string, number int32, handle func(sdk.PullRequestComments) error) error {
+	v, err := c.ListPRComments(ctx, org, repo, number)
+	if err != nil {
+		return err
+	}
+
+	return iterate(len(v), func(i int) error {
+		return handle(v[i])
+	})
+}
+
+func (c *Client) CreatePRComment(ctx context.Context, org, repo string, number int32, comment string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "create comment of pr")
+	if err != nil {
+		return err
+	}
+
+	t := now()
+	pr.comments = append(pr.comments, sdk.PullRequestComments{
+		Id:          c.nextID(),
+		Body:        comment,
+		User:        c.botBasic(),
+		CreatedAt:   t,
+		UpdatedAt:   t,
+		CommentType: "pr_comment",
+	})
+
+	c.record("CreatePRComment", org, repo, number, comment)
+	return nil
+}
+
+func (c *Client) UpdatePRComment(ctx context.Context, org, repo string, commentID int32, comment string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "update comment of pr"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return err
+	}
+
+	pr, i := r.findPRComment(commentID)
+	if pr == nil {
+		return notFound(op, "Not Found Comment")
+	}
+
+	pr.comments[i].Body = comment
+	pr.comments[i].UpdatedAt = now()
+
+	c.record("UpdatePRComment", org, repo, commentID, comment)
+	return nil
+}
+
+func (c *Client) DeletePRComment(ctx context.Context, org, repo string, ID int32) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "delete comment of pr"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return err
+	}
+
+	pr, i := r.findPRComment(ID)
+	if pr == nil {
+		return notFound(op, "Not Found Comment")
+	}
+
+	pr.comments = append(pr.comments[:i], pr.comments[i+1:]...)
+
+	c.record("DeletePRComment", org, repo, ID)
+	return nil
+}
+
+func (r *repository) findPRComment(id int32) (*pullRequest, int) {
+	for _, pr := range r.prs {
+		for i := range pr.comments {
+			if pr.comments[i].Id == id {
+				return pr, i
+			}
+		}
+	}
+	return nil, -1
+}
+
+func (c *Client) AddPRLabel(ctx context.Context, org, repo string, number int32, label string) error {
+	return c.addPRLabels(ctx, org, repo, number, []string{label}, "AddPRLabel")
+}
+
+func (c *Client) AddMultiPRLabel(ctx context.Context, org, repo string, number int32, label []string) error {
+	return c.addPRLabels(ctx, org, repo, number, label, "AddMultiPRLabel")
+}
+
+func (c *Client) addPRLabels(ctx context.Context, org, repo string, number int32, label []string, method string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, pr, err := c.getPR(ctx, org, repo, number, "add multi label for pr")
+	if err != nil {
+		return err
+	}
+
+	pr.pr.Labels = c.addLabels(r, pr.pr.Labels, label)
+
+	c.record(method, org, repo, number, label)
+	return nil
+}
+
+func (c *Client) RemovePRLabel(ctx context.Context, org, repo string, number int32, label string) error {
+	return c.removePRLabels(ctx, org, repo, number, splitLabels(label), "RemovePRLabel", label)
+}
+
+func (c *Client) RemovePRLabels(ctx context.Context, org, repo string, number int32, labels []string) error {
+	return c.removePRLabels(ctx, org, repo, number, labels, "RemovePRLabels", labels)
+}
+
+// removePRLabels removes the labels from the pr. Same as the real client,
+// it is not an error to remove the labels which the pr does not have.
+func (c *Client) removePRLabels(ctx context.Context, org, repo string, number int32, labels []string, method string, arg interface{}) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, org, repo, number, "remove label of pr")
+	if err != nil {
+		return err
+	}
+
+	pr.pr.Labels = removeLabels(pr.pr.Labels, sets.NewString(labels...))
+
+	c.record(method, org, repo, number, arg)
+	return nil
+}
+
+func (c *Client) ReplacePRAllLabels(ctx context.Context, owner, repo string, number int32, labels []string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, pr, err := c.getPR(ctx, owner, repo, number, "replace pr labels")
+	if err != nil {
+		return err
+	}
+
+	pr.pr.Labels = c.labels(r, labels)
+
+	c.record("ReplacePRAllLabels", owner, repo, number, labels)
+	return nil
+}
+
+func (c *Client) ClosePR(ctx context.Context, org, repo string, number int32) error {
+	opt := sdk.PullRequestUpdateParam{State: giteeclient.StatusClosed}
+	_, err := c.UpdatePullRequest(ctx, org, repo, number, opt)
+	return err
+}
+
+func (c *Client) AssignPR(ctx context.Context, owner, repo string, number int32, logins []string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, owner, repo, number, "assign reviewer to pr")
+	if err != nil {
+		return err
+	}
+
+	exists := sets.NewString()
+	for _, u := range pr.pr.Assignees {
+		exists.Insert(u.Login)
+	}
+
+	for _, login := range logins {
+		if !exists.Has(login) {
+			exists.Insert(login)
+			pr.pr.Assignees = append(pr.pr.Assignees, sdk.UserBasic{Login: login, Name: login})
+		}
+	}
+
+	c.record("AssignPR", owner, repo, number, logins)
+	return nil
+}
+
+func (c *Client) UnassignPR(ctx context.Context, owner, repo string, number int32, logins []string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, pr, err := c.getPR(ctx, owner, repo, number, "unassign reviewer from pr")
+	if err != nil {
+		return err
+	}
+
+	rm := sets.NewString(logins...)
+	var v []sdk.UserBasic
+	for _, u := range pr.pr.Assignees {
+		if !rm.Has(u.Login) {
+			v = append(v, u)
+		}
+	}
+	pr.pr.Assignees = v
+	pr.reviewed.Delete(logins...)
+
+	c.record("UnassignPR", owner, repo, number, logins)
+	return nil
+}
+
+func (c *Client) MergePR(ctx context.Context, owner, repo string, number int32, opt sdk.PullRequestMergePutParam) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "merge pr"
+
+	r, pr, err := c.getPR(ctx, owner, repo, number, op)
+	if err != nil {
+		return err
+	}
+
+	if pr.pr.State != giteeclient.StatusOpen {
+		return newError(op, http.StatusConflict, "the pull request is not open")
+	}
+
+	t := now()
+	pr.pr.State = stateMerged
+	pr.pr.MergedAt = t
+	pr.pr.UpdatedAt = t
+
+	if b, ok := r.branches[pr.pr.Base.Ref]; ok {
+		b.sha = c.newSHA()
+	}
+
+	c.record("MergePR", owner, repo, number, opt)
+	return nil
+}
+
+// labels returns the labels of the repository and creates the missing ones.
+func (c *Client) labels(r *repository, names []string) []sdk.Label {
+	return c.addLabels(r, nil, names)
+}
+
+func (c *Client) addLabels(r *repository, current []sdk.Label, names []string) []sdk.Label {
+	exists := labelNames(current)
+	for _, name := range names {
+		if !exists.Has(name) {
+			exists.Insert(name)
+			current = append(current, c.label(r, name))
+		}
+	}
+	return current
+}
+
+func removeLabels(current []sdk.Label, names sets.String) []sdk.Label {
+	var v []sdk.Label
+	for _, l := range current {
+		if !names.Has(l.Name) {
+			v = append(v, l)
+		}
+	}
+	return v
+}
+
+func labelNames(labels []sdk.Label) sets.String {
+	v := sets.NewString()
+	for _, l := range labels {
+		v.Insert(l.Name)
+	}
+	return v
+}
@@ -0,0 +1,269 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+// AddTag adds a lightweight tag pointing to the head of the default branch.
+// The repository is added if it does not exist.
+func (c *Client) AddTag(org, repo, tag string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r := c.ensureRepo(org, repo)
+	r.addTag(tag, r.branches[r.project.DefaultBranch].sha)
+}
+
+// AddRelease adds a release and returns its id. The tag of the release is
+// added if it does not exist. The repository is added if it does not exist.
+func (c *Client) AddRelease(org, repo string, v giteeclient.Release) int32 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r := c.ensureRepo(org, repo)
+	if _, ok := r.tags[v.TagName]; !ok {
+		r.addTag(v.TagName, r.branches[r.project.DefaultBranch].sha)
+	}
+
+	return c.addRelease(org, r, v).ID
+}
+
+func (r *repository) addTag(name, sha string) giteeclient.Tag {
+	t := giteeclient.Tag{
+		Name:   name,
+		Commit: giteeclient.TagCommit{Sha: sha, Date: now()},
+	}
+	r.tags[name] = t
+	return t
+}
+
+func (c *Client) addRelease(org string, r *repository, v giteeclient.Release) giteeclient.Release {
+	v.ID = c.nextID()
+	v.Author = c.botBasic()
+	v.CreatedAt = now()
+
+	// Gitee generates the source archives as the assets of every release.
+	base := fmt.Sprintf("https://gitee.com/%s/%s/repository/archive/%s", org, r.project.Path, v.TagName)
+	v.Assets = append(v.Assets,
+		giteeclient.ReleaseAsset{Name: v.TagName + ".zip", BrowserDownloadURL: base + ".zip"},
+		giteeclient.ReleaseAsset{Name: v.TagName + ".tar.gz", BrowserDownloadURL: base + ".tar.gz"},
+	)
+
+	r.releases[v.ID] = &v
+	return v
+}
+
+// resolveCommit returns the commit which ref, a branch or a commit sha, points to.
+func (r *repository) resolveCommit(ref string) (string, bool) {
+	if b, ok := r.resolveRef(ref); ok {
+		return b.sha, true
+	}
+	if _, ok := r.commits[ref]; ok {
+		return ref, true
+	}
+	return "", false
+}
+
+func (c *Client) getRelease(ctx context.Context, org, repo string, id int32, op string) (*repository, *giteeclient.Release, error) {
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, ok := r.releases[id]
+	if !ok {
+		return nil, nil, notFound(op, "Not Found Release")
+	}
+	return r, v, nil
+}
+
+func (c *Client) ListReleases(ctx context.Context, org, repo string) ([]giteeclient.Release, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "list releases")
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]giteeclient.Release, 0, len(r.releases))
+	for _, item := range r.releases {
+		v = append(v, *item)
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].ID < v[j].ID
+	})
+	return v, nil
+}
+
+func (c *Client) GetRelease(ctx context.Context, org, repo string, id int32) (giteeclient.Release, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, v, err := c.getRelease(ctx, org, repo, id, "get release")
+	if err != nil {
+		return giteeclient.Release{}, err
+	}
+	return *v, nil
+}
+
+func (c *Client) GetReleaseByTag(ctx context.Context, org, repo, tag string) (giteeclient.Release, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "get release by tag"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return giteeclient.Release{}, err
+	}
+
+	for _, v := range r.releases {
+		if v.TagName == tag {
+			return *v, nil
+		}
+	}
+	return giteeclient.Release{}, notFound(op, "Not Found Release")
+}
+
+// CreateRelease creates the tag from TargetCommitish if it does not exist,
+// and a tag can have only one release.
+func (c *Client) CreateRelease(ctx context.Context, org, repo string, param giteeclient.ReleaseParam) (giteeclient.Release, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "create release"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return giteeclient.Release{}, err
+	}
+
+	if param.TagName == "" || param.Name == "" {
+		return giteeclient.Release{}, newError(op, http.StatusBadRequest, "tag_name and name are required")
+	}
+
+	for _, v := range r.releases {
+		if v.TagName == param.TagName {
+			return giteeclient.Release{}, newError(op, http.StatusUnprocessableEntity, "release already exists")
+		}
+	}
+
+	if _, ok := r.tags[param.TagName]; !ok {
+		sha, ok := r.resolveCommit(param.TargetCommitish)
+		if !ok {
+			return giteeclient.Release{}, newError(op, http.StatusBadRequest, "invalid target_commitish")
+		}
+		r.addTag(param.TagName, sha)
+	}
+
+	v := c.addRelease(org, r, giteeclient.Release{
+		TagName:         param.TagName,
+		TargetCommitish: param.TargetCommitish,
+		Prerelease:      param.Prerelease,
+		Name:            param.Name,
+		Body:            param.Body,
+	})
+
+	c.record("CreateRelease", org, repo, param)
+	return v, nil
+}
+
+func (c *Client) UpdateRelease(ctx context.Context, org, repo string, id int32, param giteeclient.ReleaseParam) (giteeclient.Release, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "update release"
+
+	r, v, err := c.getRelease(ctx, org, repo, id, op)
+	if err != nil {
+		return giteeclient.Release{}, err
+	}
+
+	if param.TagName != v.TagName {
+		if _, ok := r.tags[param.TagName]; !ok {
+			return giteeclient.Release{}, newError(op, http.StatusBadRequest, "tag does not exist")
+		}
+	}
+
+	v.TagName = param.TagName
+	v.Name = param.Name
+	v.Body = param.Body
+	v.Prerelease = param.Prerelease
+
+	c.record("UpdateRelease", org, repo, id, param)
+	return *v, nil
+}
+
+// DeleteRelease deletes the release and keeps its tag as Gitee does.
+func (c *Client) DeleteRelease(ctx context.Context, org, repo string, id int32) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, _, err := c.getRelease(ctx, org, repo, id, "delete release")
+	if err != nil {
+		return err
+	}
+
+	delete(r.releases, id)
+
+	c.record("DeleteRelease", org, repo, id)
+	return nil
+}
+
+func (c *Client) ListTags(ctx context.Context, org, repo string) ([]giteeclient.Tag, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "list tags")
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]giteeclient.Tag, 0, len(r.tags))
+	for _, t := range r.tags {
+		v = append(v, t)
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Name < v[j].Name
+	})
+	return v, nil
+}
+
+// CreateTag accepts a branch as ref too, although giteeclient resolves it
+// to the commit before sending the request.
+func (c *Client) CreateTag(ctx context.Context, org, repo, tag, ref string) (giteeclient.Tag, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "create tag"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return giteeclient.Tag{}, err
+	}
+
+	if tag == "" {
+		return giteeclient.Tag{}, newError(op, http.StatusBadRequest, "tag_name is required")
+	}
+	if _, ok := r.tags[tag]; ok {
+		return giteeclient.Tag{}, newError(op, http.StatusUnprocessableEntity, "tag already exists")
+	}
+
+	sha, ok := r.resolveCommit(ref)
+	if !ok {
+		return giteeclient.Tag{}, notFound(op, "Not Found Commit")
+	}
+
+	v := r.addTag(tag, sha)
+
+	c.record("CreateTag", org, repo, tag, ref)
+	return v, nil
+}
@@ -0,0 +1,703 @@
+package fake
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+const defaultBranch = "master"
+
+type branch struct {
+	name      string
+	sha       string
+	protected bool
+}
+
+type file struct {
+	content string
+	sha     string
+}
+
+type repository struct {
+	project       sdk.Project
+	reviewer      sdk.SetRepoReviewer
+	labels        map[string]sdk.Label
+	branches      map[string]*branch
+	files         map[string]map[string]*file // branch -> path -> file
+	collaborators map[string]string           // login -> permission
+	commits       map[string]sdk.RepoCommit
+	prs           map[int32]*pullRequest
+	issues        map[string]*issue
+	milestones    map[int32]*sdk.Milestone
+	hooks         map[int32]*giteeclient.Hook
+	rules         map[string]*giteeclient.BranchProtectionRule // wildcard -> rule
+	releases      map[int32]*giteeclient.Release
+	tags          map[string]giteeclient.Tag
+	lastPRNumber  int32
+	lastMilestone int32
+}
+
+// AddRepo adds the repository org/repo whose default branch is master.
+func (c *Client) AddRepo(org, repo string) {
+	c.AddProject(org, sdk.Project{Path: repo, Name: repo})
+}
+
+// AddProject adds the repository described by p to org. p.Path is required.
+func (c *Client) AddProject(org string, p sdk.Project) {
+	c.mut.Lock()
+	c.addProject(org, p)
+	c.mut.Unlock()
+}
+
+// AddBranch adds a branch whose files are copied from the default branch.
+// The repository is added if it does not exist.
+func (c *Client) AddBranch(org, repo, branchName string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r := c.ensureRepo(org, repo)
+	r.addBranch(branchName, r.project.DefaultBranch, c.newSHA())
+}
+
+// AddFile adds a file to the branch, and the branch is added if it does not exist.
+func (c *Client) AddFile(org, repo, branchName, filePath, content string) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r := c.ensureRepo(org, repo)
+	if _, ok := r.branches[branchName]; !ok {
+		r.addBranch(branchName, r.project.DefaultBranch, c.newSHA())
+	}
+
+	sha := c.newSHA()
+	r.files[branchName][filePath] = &file{content: content, sha: sha}
+	r.branches[branchName].sha = c.newSHA()
+}
+
+// AddCollaborator adds login as a collaborator of org/repo with permission
+// which is one of pull, push and admin.
+func (c *Client) AddCollaborator(org, repo, login, permission string) {
+	c.mut.Lock()
+	c.ensureRepo(org, repo).collaborators[login] = permission
+	c.mut.Unlock()
+}
+
+// AddCommit adds a commit which can be got by GetPRCommit.
+func (c *Client) AddCommit(org, repo string, commit sdk.RepoCommit) {
+	c.mut.Lock()
+	c.ensureRepo(org, repo).commits[commit.Sha] = commit
+	c.mut.Unlock()
+}
+
+func (c *Client) addProject(org string, p sdk.Project) *repository {
+	if p.Name == "" {
+		p.Name = p.Path
+	}
+	if p.DefaultBranch == "" {
+		p.DefaultBranch = defaultBranch
+	}
+	if p.Id == 0 {
+		p.Id = c.nextID()
+	}
+	p.FullName = repoKey(org, p.Path)
+	p.HumanName = p.FullName
+	p.HtmlUrl = "https://gitee.com/" + p.FullName
+	p.Namespace = &sdk.Namespace{Path: org, Name: org}
+	p.Owner = &sdk.UserBasic{Login: org}
+	p.Public = !p.Private
+
+	r := &repository{
+		project:       p,
+		labels:        map[string]sdk.Label{},
+		branches:      map[string]*branch{},
+		files:         map[string]map[string]*file{},
+		collaborators: map[string]string{},
+		commits:       map[string]sdk.RepoCommit{},
+		prs:           map[int32]*pullRequest{},
+		issues:        map[string]*issue{},
+		milestones:    map[int32]*sdk.Milestone{},
+		hooks:         map[int32]*giteeclient.Hook{},
+		rules:         map[string]*giteeclient.BranchProtectionRule{},
+		releases:      map[int32]*giteeclient.Release{},
+		tags:          map[string]giteeclient.Tag{},
+	}
+	r.addBranch(p.DefaultBranch, "", c.newSHA())
+
+	c.repos[p.FullName] = r
+	return r
+}
+
+func (c *Client) ensureRepo(org, repo string) *repository {
+	if r, ok := c.repos[repoKey(org, repo)]; ok {
+		return r
+	}
+	return c.addProject(org, sdk.Project{Path: repo})
+}
+
+func (c *Client) newSHA() string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprint(c.nextID()))))
+}
+
+func (r *repository) addBranch(name, parent, sha string) {
+	files := map[string]*file{}
+	for k, v := range r.files[parent] {
+		f := *v
+		files[k] = &f
+	}
+
+	r.files[name] = files
+	r.branches[name] = &branch{name: name, sha: sha}
+}
+
+// resolveRef returns the branch whose name or head is ref.
+func (r *repository) resolveRef(ref string) (*branch, bool) {
+	ref = strings.TrimPrefix(ref, "heads/")
+	if ref == "" {
+		ref = r.project.DefaultBranch
+	}
+
+	if b, ok := r.branches[ref]; ok {
+		return b, true
+	}
+
+	for _, b := range r.branches {
+		if b.sha == ref {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// label returns the label of the repository and creates it if it does not exist.
+func (c *Client) label(r *repository, name string) sdk.Label {
+	if l, ok := r.labels[name]; ok {
+		return l
+	}
+
+	l := sdk.Label{
+		Id:           c.nextID(),
+		Name:         name,
+		Color:        "ededed",
+		RepositoryId: r.project.Id,
+		CreatedAt:    now(),
+	}
+	r.labels[name] = l
+	return l
+}
+
+func (c *Client) GetRepos(ctx context.Context, org string) ([]sdk.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	var r []sdk.Project
+	for _, v := range c.repos {
+		if v.project.Namespace.Path == org {
+			r = append(r, v.project)
+		}
+	}
+
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].Path < r[j].Path
+	})
+	return r, nil
+}
+
+func (c *Client) IterateRepos(ctx context.Context, org string, handle func(sdk.Project) error) error {
+	v, err := c.GetRepos(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	return iterate(len(v), func(i int) error {
+		return handle(v[i])
+	})
+}
+
+func (c *Client) CreateRepo(ctx context.Context, org string, repo sdk.RepositoryPostParam) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	p := repo.Path
+	if p == "" {
+		p = repo.Name
+	}
+
+	if _, ok := c.repos[repoKey(org, p)]; ok {
+		return newError("create repo", http.StatusUnprocessableEntity, "repository already exists")
+	}
+
+	c.addProject(org, sdk.Project{
+		Path:        p,
+		Name:        repo.Name,
+		Description: repo.Description,
+		Private:     repo.Private,
+	})
+	c.record("CreateRepo", org, repo)
+	return nil
+}
+
+func (c *Client) UpdateRepo(ctx context.Context, org, repo string, info sdk.RepoPatchParam) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "update repo")
+	if err != nil {
+		return err
+	}
+
+	if info.DefaultBranch != "" {
+		if _, ok := r.branches[info.DefaultBranch]; !ok {
+			return newError("update repo", http.StatusUnprocessableEntity, "default branch does not exist")
+		}
+		r.project.DefaultBranch = info.DefaultBranch
+	}
+	if info.Name != "" {
+		r.project.Name = info.Name
+	}
+	if info.Description != "" {
+		r.project.Description = info.Description
+	}
+	r.project.UpdatedAt = now()
+
+	c.record("UpdateRepo", org, repo, info)
+	return nil
+}
+
+func (c *Client) GetRepo(ctx context.Context, org, repo string) (sdk.Project, error) {
+	return c.GetGiteeRepo(ctx, org, repo)
+}
+
+func (c *Client) GetGiteeRepo(ctx context.Context, org, repo string) (sdk.Project, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "get repo")
+	if err != nil {
+		return sdk.Project{}, err
+	}
+	return r.project, nil
+}
+
+func (c *Client) SetRepoReviewer(ctx context.Context, org, repo string, reviewer sdk.SetRepoReviewer) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "set repo reviewer")
+	if err != nil {
+		return err
+	}
+
+	r.reviewer = reviewer
+	c.record("SetRepoReviewer", org, repo, reviewer)
+	return nil
+}
+
+func (c *Client) CreateRepoLabel(ctx context.Context, org, repo, label, color string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "create a repo label")
+	if err != nil {
+		return err
+	}
+
+	if _, ok := r.labels[label]; ok {
+		return newError("create a repo label", http.StatusUnprocessableEntity, "label already exists")
+	}
+
+	l := c.label(r, label)
+	if color != "" {
+		l.Color = color
+		r.labels[label] = l
+	}
+
+	c.record("CreateRepoLabel", org, repo, label, color)
+	return nil
+}
+
+func (c *Client) GetRepoLabels(ctx context.Context, owner, repo string) ([]sdk.Label, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, owner, repo, "get repo labels")
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]sdk.Label, 0, len(r.labels))
+	for _, l := range r.labels {
+		v = append(v, l)
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Id < v[j].Id
+	})
+	return v, nil
+}
+
+func (c *Client) ListCollaborators(ctx context.Context, org, repo string) ([]sdk.ProjectMember, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "list collaborators")
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]sdk.ProjectMember, 0, len(r.collaborators))
+	for login, permission := range r.collaborators {
+		v = append(v, sdk.ProjectMember{
+			Login: login,
+			Name:  login,
+			Permissions: &sdk.ProjectMemberPermissions{
+				Pull:  true,
+				Push:  permission == "push" || permission == "admin",
+				Admin: permission == "admin",
+			},
+		})
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Login < v[j].Login
+	})
+	return v, nil
+}
+
+func (c *Client) IterateCollaborators(ctx context.Context, org, repo string, handle func(sdk.ProjectMember) error) error {
+	v, err := c.ListCollaborators(ctx, org, repo)
+	if err != nil {
+		return err
+	}
+
+	return iterate(len(v), func(i int) error {
+		return handle(v[i])
+	})
+}
+
+func (c *Client) IsCollaborator(ctx context.Context, owner, repo, login string) (bool, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, owner, repo, "get collaborator of pr")
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := r.collaborators[login]
+	return ok, nil
+}
+
+func (c *Client) IsMember(ctx context.Context, org, login string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, ok := c.orgMembers[org][login]
+	return ok, nil
+}
+
+func (c *Client) RemoveRepoMember(ctx context.Context, org, repo, login string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "remove repo member")
+	if err != nil {
+		return err
+	}
+
+	// Same as the real client, it is not an error to remove a non-member.
+	if _, ok := r.collaborators[login]; ok {
+		delete(r.collaborators, login)
+		c.record("RemoveRepoMember", org, repo, login)
+	}
+	return nil
+}
+
+func (c *Client) AddRepoMember(ctx context.Context, org, repo, login, permission string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "add repo member")
+	if err != nil {
+		return err
+	}
+
+	r.collaborators[login] = permission
+	c.record("AddRepoMember", org, repo, login, permission)
+	return nil
+}
+
+func (c *Client) GetUserPermissionsOfRepo(ctx context.Context, org, repo, login string) (sdk.ProjectMemberPermission, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "get user permissions")
+	if err != nil {
+		return sdk.ProjectMemberPermission{}, err
+	}
+
+	permission, ok := r.collaborators[login]
+	if !ok {
+		return sdk.ProjectMemberPermission{}, notFound("get user permissions", "Not Found User")
+	}
+
+	return sdk.ProjectMemberPermission{
+		Permission: permission,
+		User:       &sdk.UserBasic{Login: login, Name: login},
+	}, nil
+}
+
+func (c *Client) CreateBranch(ctx context.Context, org, repo, branchName, parentBranch string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "create a branch")
+	if err != nil {
+		return err
+	}
+
+	parent, ok := r.resolveRef(parentBranch)
+	if !ok {
+		return notFound("create a branch", "Not Found Branch")
+	}
+
+	if _, ok := r.branches[branchName]; ok {
+		return newError("create a branch", http.StatusUnprocessableEntity, "branch already exists")
+	}
+
+	r.addBranch(branchName, parent.name, parent.sha)
+	c.record("CreateBranch", org, repo, branchName, parentBranch)
+	return nil
+}
+
+func (c *Client) GetRepoAllBranch(ctx context.Context, org, repo string) ([]sdk.Branch, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "get repo all branch")
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]sdk.Branch, 0, len(r.branches))
+	for _, b := range r.branches {
+		v = append(v, sdk.Branch{
+			Name:      b.name,
+			Protected: b.protected || r.matchRule(b.name),
+			Commit:    &sdk.BranchCommit{Sha: b.sha},
+		})
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Name < v[j].Name
+	})
+	return v, nil
+}
+
+func (c *Client) SetProtectionBranch(ctx context.Context, org, repo, branchName string) error {
+	return c.setProtectionBranch(ctx, org, repo, branchName, true, "SetProtectionBranch")
+}
+
+func (c *Client) CancelProtectionBranch(ctx context.Context, org, repo, branchName string) error {
+	return c.setProtectionBranch(ctx, org, repo, branchName, false, "CancelProtectionBranch")
+}
+
+func (c *Client) setProtectionBranch(ctx context.Context, org, repo, branchName string, protected bool, method string) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "set protection branch")
+	if err != nil {
+		return err
+	}
+
+	b, ok := r.branches[branchName]
+	if !ok {
+		return notFound("set protection branch", "Not Found Branch")
+	}
+
+	b.protected = protected
+	c.record(method, org, repo, branchName)
+	return nil
+}
+
+func (c *Client) GetRef(ctx context.Context, org, repo, ref string) (string, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "get branch")
+	if err != nil {
+		return "", err
+	}
+
+	b, ok := r.branches[strings.TrimPrefix(ref, "heads/")]
+	if !ok {
+		return "", notFound("get branch", "Not Found Branch")
+	}
+	return b.sha, nil
+}
+
+func (c *Client) GetPRCommit(ctx context.Context, org, repo, SHA string) (sdk.RepoCommit, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "get commit info")
+	if err != nil {
+		return sdk.RepoCommit{}, err
+	}
+
+	v, ok := r.commits[SHA]
+	if !ok {
+		return v, notFound("get commit info", "Not Found Commit")
+	}
+	return v, nil
+}
+
+func (c *Client) CreateFile(ctx context.Context, org, repo, branchName, filePath, content, commitMsg string) (sdk.CommitContent, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "create file")
+	if err != nil {
+		return sdk.CommitContent{}, err
+	}
+
+	b, ok := r.resolveRef(branchName)
+	if !ok {
+		return sdk.CommitContent{}, notFound("create file", "Not Found Branch")
+	}
+
+	files := r.files[b.name]
+	if _, ok := files[filePath]; ok {
+		return sdk.CommitContent{}, newError("create file", http.StatusUnprocessableEntity, "file already exists")
+	}
+
+	f := &file{content: content, sha: c.newSHA()}
+	files[filePath] = f
+	b.sha = c.newSHA()
+
+	c.record("CreateFile", org, repo, branchName, filePath, content, commitMsg)
+
+	return sdk.CommitContent{
+		Content: &sdk.ContentBasic{
+			Name:  path.Base(filePath),
+			Path:  filePath,
+			Size:  int32(len(content)),
+			Sha:   f.sha,
+			Type_: "file",
+		},
+		Commit: &sdk.Commit{Sha: b.sha, Message: commitMsg},
+	}, nil
+}
+
+func (c *Client) GetPathContent(ctx context.Context, org, repo, filePath, ref string) (sdk.Content, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "get path content")
+	if err != nil {
+		return sdk.Content{}, err
+	}
+
+	b, ok := r.resolveRef(ref)
+	if !ok {
+		return sdk.Content{}, notFound("get path content", "Not Found Ref")
+	}
+
+	f, ok := r.files[b.name][filePath]
+	if !ok {
+		return sdk.Content{}, notFound("get path content", "file does not exist")
+	}
+
+	return sdk.Content{
+		Type_:       "file",
+		Encoding:    "base64",
+		Size:        int32(len(f.content)),
+		Name:        path.Base(filePath),
+		Path:        filePath,
+		Content:     base64.StdEncoding.EncodeToString([]byte(f.content)),
+		Sha:         f.sha,
+		DownloadUrl: fmt.Sprintf("%s/raw/%s/%s", r.project.HtmlUrl, b.name, filePath),
+	}, nil
+}
+
+func (c *Client) GetDirectoryTree(ctx context.Context, org, repo, sha string, recursive int32) (sdk.Tree, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "get directory tree")
+	if err != nil {
+		return sdk.Tree{}, err
+	}
+
+	b, ok := r.resolveRef(sha)
+	if !ok {
+		return sdk.Tree{}, notFound("get directory tree", "Not Found Tree")
+	}
+
+	entries := map[string]sdk.TreeBasic{}
+	for p, f := range r.files[b.name] {
+		parts := strings.Split(p, "/")
+		if recursive != 1 {
+			if len(parts) > 1 {
+				entries[parts[0]] = sdk.TreeBasic{Path: parts[0], Mode: "040000", Type_: "tree"}
+				continue
+			}
+		} else {
+			for i := 1; i < len(parts); i++ {
+				dir := strings.Join(parts[:i], "/")
+				entries[dir] = sdk.TreeBasic{Path: dir, Mode: "040000", Type_: "tree"}
+			}
+		}
+
+		entries[p] = sdk.TreeBasic{
+			Path:  p,
+			Mode:  "100644",
+			Type_: "blob",
+			Sha:   f.sha,
+			Size:  int32(len(f.content)),
+		}
+	}
+
+	tree := sdk.Tree{Sha: b.sha, Tree: make([]sdk.TreeBasic, 0, len(entries))}
+	for _, v := range entries {
+		tree.Tree = append(tree.Tree, v)
+	}
+
+	sort.Slice(tree.Tree, func(i, j int) bool {
+		return tree.Tree[i].Path < tree.Tree[j].Path
+	})
+	return tree, nil
+}
+
+func (c *Client) GetBot(ctx context.Context) (sdk.User, error) {
+	if err := ctx.Err(); err != nil {
+		return sdk.User{}, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.bot, nil
+}
@@ -0,0 +1,184 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"path"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+// getFile returns the file on the branch, and a conflict error if sha is not
+// the sha of the file, which means the file has been changed since sha was read.
+func (c *Client) getFile(ctx context.Context, org, repo, branchName, filePath, sha, op string) (*repository, *branch, *file, error) {
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	b, ok := r.resolveRef(branchName)
+	if !ok {
+		return nil, nil, nil, notFound(op, "Not Found Branch")
+	}
+
+	f, ok := r.files[b.name][filePath]
+	if !ok {
+		return nil, nil, nil, notFound(op, "file does not exist")
+	}
+
+	if sha == "" {
+		return nil, nil, nil, newError(op, http.StatusBadRequest, "sha is missing")
+	}
+	if sha != f.sha {
+		return nil, nil, nil, newError(op, http.StatusConflict, "the file has been changed")
+	}
+
+	return r, b, f, nil
+}
+
+func (c *Client) UpdateFile(ctx context.Context, org, repo, branchName, filePath, content, sha, commitMsg string) (sdk.CommitContent, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, b, f, err := c.getFile(ctx, org, repo, branchName, filePath, sha, "update file")
+	if err != nil {
+		return sdk.CommitContent{}, err
+	}
+
+	f.content = content
+	f.sha = c.newSHA()
+	b.sha = c.newSHA()
+
+	c.record("UpdateFile", org, repo, branchName, filePath, content, sha, commitMsg)
+
+	return sdk.CommitContent{
+		Content: &sdk.ContentBasic{
+			Name:  path.Base(filePath),
+			Path:  filePath,
+			Size:  int32(len(content)),
+			Sha:   f.sha,
+			Type_: "file",
+		},
+		Commit: &sdk.Commit{Sha: b.sha, Message: commitMsg},
+	}, nil
+}
+
+func (c *Client) DeleteFile(ctx context.Context, org, repo, branchName, filePath, sha, commitMsg string) (sdk.CommitContent, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, b, _, err := c.getFile(ctx, org, repo, branchName, filePath, sha, "delete file")
+	if err != nil {
+		return sdk.CommitContent{}, err
+	}
+
+	delete(r.files[b.name], filePath)
+	b.sha = c.newSHA()
+
+	c.record("DeleteFile", org, repo, branchName, filePath, sha, commitMsg)
+
+	return sdk.CommitContent{Commit: &sdk.Commit{Sha: b.sha, Message: commitMsg}}, nil
+}
+
+// CommitFiles applies the changes to a copy of the files on the branch, and
+// the branch is changed only if all of them are applied.
+func (c *Client) CommitFiles(ctx context.Context, org, repo, branchName, commitMsg string, changes []giteeclient.FileChange) (sdk.RepoCommit, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "commit files"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return sdk.RepoCommit{}, err
+	}
+
+	b, ok := r.resolveRef(branchName)
+	if !ok {
+		return sdk.RepoCommit{}, notFound(op, "Not Found Branch")
+	}
+
+	if commitMsg == "" || len(changes) == 0 {
+		return sdk.RepoCommit{}, newError(op, http.StatusBadRequest, "message and actions are required")
+	}
+
+	files := map[string]*file{}
+	for k, v := range r.files[b.name] {
+		files[k] = v
+	}
+
+	for _, item := range changes {
+		if err := c.applyFileChange(files, item, op); err != nil {
+			return sdk.RepoCommit{}, err
+		}
+	}
+
+	r.files[b.name] = files
+	b.sha = c.newSHA()
+
+	commit := sdk.RepoCommit{
+		Sha:    b.sha,
+		Author: c.botBasic(),
+		Commit: &sdk.GitCommit{
+			Author:  &sdk.GitUser{Name: c.bot.Name, Date: now()},
+			Message: commitMsg,
+		},
+	}
+	r.commits[commit.Sha] = commit
+
+	c.record("CommitFiles", org, repo, branchName, commitMsg, changes)
+	return commit, nil
+}
+
+// applyFileChange applies the change to files. The files changed are replaced
+// instead of being modified, so that the files of the branch are kept if it fails.
+func (c *Client) applyFileChange(files map[string]*file, item giteeclient.FileChange, op string) error {
+	if item.Path == "" {
+		return newError(op, http.StatusBadRequest, "path is missing")
+	}
+
+	_, exists := files[item.Path]
+
+	switch item.Action {
+	case giteeclient.FileActionCreate:
+		if exists {
+			return newError(op, http.StatusConflict, "file already exists: "+item.Path)
+		}
+		files[item.Path] = &file{content: item.Content, sha: c.newSHA()}
+
+	case giteeclient.FileActionUpdate:
+		if !exists {
+			return newError(op, http.StatusConflict, "file does not exist: "+item.Path)
+		}
+		files[item.Path] = &file{content: item.Content, sha: c.newSHA()}
+
+	case giteeclient.FileActionDelete:
+		if !exists {
+			return newError(op, http.StatusConflict, "file does not exist: "+item.Path)
+		}
+		delete(files, item.Path)
+
+	case giteeclient.FileActionMove:
+		f, ok := files[item.PreviousPath]
+		if !ok {
+			return newError(op, http.StatusConflict, "file does not exist: "+item.PreviousPath)
+		}
+		if exists {
+			return newError(op, http.StatusConflict, "file already exists: "+item.Path)
+		}
+
+		v := *f
+		if item.Content != "" {
+			v = file{content: item.Content, sha: c.newSHA()}
+		}
+		delete(files, item.PreviousPath)
+		files[item.Path] = &v
+
+	default:
+		return newError(op, http.StatusBadRequest, "invalid action: "+item.Action)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package fake
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+// AddRepoHook adds a webhook to org/repo and returns its id.
+// The repository is added if it does not exist.
+func (c *Client) AddRepoHook(org, repo string, param giteeclient.HookParam) int32 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.addRepoHook(c.ensureRepo(org, repo), param).ID
+}
+
+func (c *Client) addRepoHook(r *repository, param giteeclient.HookParam) giteeclient.Hook {
+	h := giteeclient.Hook{
+		HookEvents:     param.HookEvents,
+		ID:             c.nextID(),
+		URL:            param.URL,
+		Password:       param.Password,
+		EncryptionType: param.EncryptionType,
+		ProjectID:      r.project.Id,
+		CreatedAt:      now(),
+	}
+
+	r.hooks[h.ID] = &h
+	return h
+}
+
+func (c *Client) getRepoHook(ctx context.Context, org, repo string, id int32, op string) (*repository, *giteeclient.Hook, error) {
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h, ok := r.hooks[id]
+	if !ok {
+		return nil, nil, notFound(op, "Not Found Hook")
+	}
+	return r, h, nil
+}
+
+func (c *Client) ListRepoHooks(ctx context.Context, org, repo string) ([]giteeclient.Hook, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, err := c.getRepo(ctx, org, repo, "list repo hooks")
+	if err != nil {
+		return nil, err
+	}
+
+	v := make([]giteeclient.Hook, 0, len(r.hooks))
+	for _, h := range r.hooks {
+		v = append(v, *h)
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].ID < v[j].ID
+	})
+	return v, nil
+}
+
+func (c *Client) CreateRepoHook(ctx context.Context, org, repo string, param giteeclient.HookParam) (giteeclient.Hook, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "create repo hook"
+
+	r, err := c.getRepo(ctx, org, repo, op)
+	if err != nil {
+		return giteeclient.Hook{}, err
+	}
+
+	if err := checkHookParam(param, op); err != nil {
+		return giteeclient.Hook{}, err
+	}
+
+	h := c.addRepoHook(r, param)
+
+	c.record("CreateRepoHook", org, repo, param)
+	return h, nil
+}
+
+func (c *Client) UpdateRepoHook(ctx context.Context, org, repo string, id int32, param giteeclient.HookParam) (giteeclient.Hook, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	op := "update repo hook"
+
+	_, h, err := c.getRepoHook(ctx, org, repo, id, op)
+	if err != nil {
+		return giteeclient.Hook{}, err
+	}
+
+	if err := checkHookParam(param, op); err != nil {
+		return giteeclient.Hook{}, err
+	}
+
+	h.HookEvents = param.HookEvents
+	h.URL = param.URL
+	h.EncryptionType = param.EncryptionType
+	if param.Password != "" {
+		h.Password = param.Password
+	}
+
+	c.record("UpdateRepoHook", org, repo, id, param)
+	return *h, nil
+}
+
+// TestRepoHook doesn't deliver anything, but records the result as a successful delivery.
+func (c *Client) TestRepoHook(ctx context.Context, org, repo string, id int32) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	_, h, err := c.getRepoHook(ctx, org, repo, id, "test repo hook")
+	if err != nil {
+		return err
+	}
+
+	h.Result = "ok"
+	h.ResultCode = http.StatusOK
+
+	c.record("TestRepoHook", org, repo, id)
+	return nil
+}
+
+func (c *Client) DeleteRepoHook(ctx context.Context, org, repo string, id int32) error {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	r, _, err := c.getRepoHook(ctx, org, repo, id, "delete repo hook")
+	if err != nil {
+		return err
+	}
+
+	delete(r.hooks, id)
+
+	c.record("DeleteRepoHook", org, repo, id)
+	return nil
+}
+
+func checkHookParam(param giteeclient.HookParam, op string) error {
+	if param.URL == "" {
+		return newError(op, http.StatusBadRequest, "url is missing")
+	}
+
+	switch param.EncryptionType {
+	case giteeclient.HookEncryptionPassword, giteeclient.HookEncryptionSignKey:
+		return nil
+	}
+	return newError(op, http.StatusBadRequest, "invalid encryption_type")
+}
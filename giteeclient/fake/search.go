@@ -0,0 +1,126 @@
+package fake
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+// containsFold reports whether any of texts contains q case-insensitively.
+func containsFold(q string, texts ...string) bool {
+	q = strings.ToLower(q)
+	for _, s := range texts {
+		if strings.Contains(strings.ToLower(s), q) {
+			return true
+		}
+	}
+	return false
+}
+
+// SearchRepos returns the matched repositories in the order of creation.
+// Language, Sort and Order are ignored, since the fake doesn't know them.
+func (c *Client) SearchRepos(ctx context.Context, q string, opts giteeclient.SearchRepoOpt) ([]sdk.Project, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	var v []sdk.Project
+	for _, r := range c.repos {
+		p := r.project
+
+		if opts.Owner != "" && p.Namespace.Path != opts.Owner {
+			continue
+		}
+		if p.Fork && !opts.Fork {
+			continue
+		}
+		if !containsFold(q, p.Path, p.Name, p.Description) {
+			continue
+		}
+
+		v = append(v, p)
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Id < v[j].Id
+	})
+	return v, nil
+}
+
+// SearchIssues returns the matched issues in the order of creation.
+// Language, Sort and Order are ignored as SearchRepos does.
+func (c *Client) SearchIssues(ctx context.Context, q string, opts giteeclient.SearchIssueOpt) ([]sdk.Issue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	var v []sdk.Issue
+	for key, r := range c.repos {
+		if opts.Repo != "" && key != opts.Repo {
+			continue
+		}
+
+		for _, item := range r.issues {
+			i := item.issue
+
+			if opts.State != "" && opts.State != stateAll && i.State != opts.State {
+				continue
+			}
+			if opts.Label != "" && !labelNames(i.Labels).Has(opts.Label) {
+				continue
+			}
+			if opts.Author != "" && (i.User == nil || i.User.Login != opts.Author) {
+				continue
+			}
+			if opts.Assignee != "" && (i.Assignee == nil || i.Assignee.Login != opts.Assignee) {
+				continue
+			}
+			if !containsFold(q, i.Title, i.Body) {
+				continue
+			}
+
+			v = append(v, i)
+		}
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Id < v[j].Id
+	})
+	return v, nil
+}
+
+// SearchUsers returns the matched users, including the bot, in the order
+// of login. Sort and Order are ignored.
+func (c *Client) SearchUsers(ctx context.Context, q string, opts giteeclient.SearchUserOpt) ([]sdk.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	var v []sdk.User
+	if containsFold(q, c.bot.Login, c.bot.Name) {
+		v = append(v, c.bot)
+	}
+	for _, u := range c.users {
+		if !strings.EqualFold(u.Login, c.bot.Login) && containsFold(q, u.Login, u.Name) {
+			v = append(v, u)
+		}
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Login < v[j].Login
+	})
+	return v, nil
+}
@@ -0,0 +1,79 @@
+package fake
+
+import (
+	"context"
+	"strings"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+// AddUser adds a user which can be looked up by GetUser. u.Login is required.
+func (c *Client) AddUser(u sdk.User) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if u.Id == 0 {
+		u.Id = c.nextID()
+	}
+	if u.Name == "" {
+		u.Name = u.Login
+	}
+	u.HtmlUrl = "https://gitee.com/" + u.Login
+	c.users[strings.ToLower(u.Login)] = u
+}
+
+func (c *Client) getUser(ctx context.Context, login string) (sdk.User, error) {
+	if err := ctx.Err(); err != nil {
+		return sdk.User{}, err
+	}
+
+	if strings.EqualFold(login, c.bot.Login) {
+		return c.bot, nil
+	}
+
+	if u, ok := c.users[strings.ToLower(login)]; ok {
+		return u, nil
+	}
+	return sdk.User{}, notFound("get user", "Not Found User")
+}
+
+func (c *Client) GetUser(ctx context.Context, login string) (sdk.User, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	return c.getUser(ctx, login)
+}
+
+func (c *Client) GetUserEmail(ctx context.Context, login string) (string, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	u, err := c.getUser(ctx, login)
+	if err != nil {
+		return "", err
+	}
+	return u.Email, nil
+}
+
+// ListBotEmails returns the email of the bot, which is set by SetBot,
+// as the primary one.
+func (c *Client) ListBotEmails(ctx context.Context) ([]giteeclient.UserEmail, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.bot.Email == "" {
+		return nil, nil
+	}
+
+	return []giteeclient.UserEmail{{
+		Email: c.bot.Email,
+		State: "confirmed",
+		Scope: []string{"primary", "committed"},
+	}}, nil
+}
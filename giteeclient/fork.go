@@ -0,0 +1,127 @@
+package giteeclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"github.com/antihax/optional"
+)
+
+const (
+	// forkPollInterval is the interval to check whether a fork is ready.
+	forkPollInterval = 2 * time.Second
+
+	// forkWaitTimeout is how long WaitForFork waits for a fork at most.
+	forkWaitTimeout = 5 * time.Minute
+)
+
+// ForkRepo forks org/repo into namespace, which is an organization the bot
+// belongs to. It is forked into the account of bot if namespace is empty.
+// Gitee creates the fork asynchronously, so call WaitForFork before using it.
+func (c *client) ForkRepo(ctx context.Context, org, repo, namespace string) (sdk.Project, error) {
+	ctx = withMethod(ctx, "ForkRepo")
+
+	v, r, err := c.ac.RepositoriesApi.PostV5ReposOwnerRepoForks(
+		ctx, org, repo, sdk.ForkPostParam{Organization: namespace},
+	)
+	return v, formatErr(err, r, "fork repo")
+}
+
+func (c *client) ListForks(ctx context.Context, org, repo string) ([]sdk.Project, error) {
+	ctx = withMethod(ctx, "ListForks")
+
+	opt := sdk.GetV5ReposOwnerRepoForksOpts{}
+
+	var r []sdk.Project
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		v, resp, err := c.ac.RepositoriesApi.GetV5ReposOwnerRepoForks(ctx, org, repo, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "list forks")
+		}
+
+		r = append(r, v...)
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetBotFork returns the fork of org/repo in the account of bot, and
+// the not found error if the bot has not forked it.
+func (c *client) GetBotFork(ctx context.Context, org, repo string) (sdk.Project, error) {
+	ctx = withMethod(ctx, "GetBotFork")
+
+	bot, err := c.GetBot(ctx)
+	if err != nil {
+		return sdk.Project{}, err
+	}
+
+	// The fork is named after the upstream unless it is renamed, so try it
+	// first to avoid listing the forks which may be a lot.
+	v, err := c.GetRepo(ctx, bot.Login, repo)
+	if err == nil && isForkOf(v, org, repo) {
+		return v, nil
+	}
+	if err != nil && !IsNotFound(err) {
+		return sdk.Project{}, err
+	}
+
+	forks, err := c.ListForks(ctx, org, repo)
+	if err != nil {
+		return sdk.Project{}, err
+	}
+
+	for i := range forks {
+		if ns := forks[i].Namespace; ns != nil && strings.EqualFold(ns.Path, bot.Login) {
+			return forks[i], nil
+		}
+	}
+
+	return sdk.Project{}, &Error{
+		Op:         "get bot fork",
+		StatusCode: http.StatusNotFound,
+		err:        errors.New("the bot has not forked the repository"),
+	}
+}
+
+// WaitForFork waits until the fork org/repo is ready, that is, it exists and
+// its default branch has been created. It checks every forkPollInterval until
+// the fork is ready or ctx is done, and gives up after forkWaitTimeout even if
+// ctx has no deadline.
+func (c *client) WaitForFork(ctx context.Context, org, repo string) (sdk.Project, error) {
+	ctx = withMethod(ctx, "WaitForFork")
+
+	ctx, cancel := context.WithTimeout(ctx, forkWaitTimeout)
+	defer cancel()
+
+	for {
+		v, err := c.GetRepo(ctx, org, repo)
+		if err == nil && v.DefaultBranch != "" {
+			if _, err = c.GetRef(ctx, org, repo, v.DefaultBranch); err == nil {
+				return v, nil
+			}
+		}
+		if err != nil && !IsNotFound(err) {
+			return sdk.Project{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return sdk.Project{}, ctx.Err()
+		case <-time.After(forkPollInterval):
+		}
+	}
+}
+
+func isForkOf(p sdk.Project, org, repo string) bool {
+	return p.Fork && p.Parent != nil && strings.EqualFold(p.Parent.FullName, org+"/"+repo)
+}
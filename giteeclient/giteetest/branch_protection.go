@@ -0,0 +1,89 @@
+package giteetest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+// branchProtectionRule is the rule in the format of Gitee.
+type branchProtectionRule struct {
+	ID          int32  `json:"id,omitempty"`
+	Wildcard    string `json:"wildcard"`
+	Pusher      string `json:"pusher"`
+	Merger      string `json:"merger"`
+	ReviewCount int32  `json:"review_count"`
+}
+
+func toGiteeRule(v giteeclient.BranchProtectionRule) branchProtectionRule {
+	return branchProtectionRule{
+		ID:          v.ID,
+		Wildcard:    v.Wildcard,
+		Pusher:      strings.Join(v.Pushers, ";"),
+		Merger:      strings.Join(v.Mergers, ";"),
+		ReviewCount: v.ReviewCount,
+	}
+}
+
+func (r branchProtectionRule) toRule() giteeclient.BranchProtectionRule {
+	split := func(s string) []string {
+		if s == "" {
+			return nil
+		}
+		return strings.Split(s, ";")
+	}
+
+	return giteeclient.BranchProtectionRule{
+		Wildcard:    r.Wildcard,
+		Pushers:     split(r.Pusher),
+		Mergers:     split(r.Merger),
+		ReviewCount: r.ReviewCount,
+	}
+}
+
+func (s *Server) registerBranchProtectionRoutes() {
+	s.handle("GET", "/repos/:owner/:repo/branches/setting", s.listBranchProtectionRules)
+	s.handle("PUT", "/repos/:owner/:repo/branches/setting/new", s.createBranchProtectionRule)
+	s.handle("PUT", "/repos/:owner/:repo/branches/:wildcard/setting", s.updateBranchProtectionRule)
+	s.handle("DELETE", "/repos/:owner/:repo/branches/:wildcard/setting", s.deleteBranchProtectionRule)
+}
+
+func (s *Server) listBranchProtectionRules(w http.ResponseWriter, r *http.Request, p params) {
+	rules, err := s.Fake.ListBranchProtectionRules(r.Context(), p["owner"], p["repo"])
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v := make([]branchProtectionRule, len(rules))
+	for i := range rules {
+		v[i] = toGiteeRule(rules[i])
+	}
+	writeJSON(w, http.StatusOK, v)
+}
+
+func (s *Server) createBranchProtectionRule(w http.ResponseWriter, r *http.Request, p params) {
+	var param branchProtectionRule
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	v, err := s.Fake.CreateBranchProtectionRule(r.Context(), p["owner"], p["repo"], param.toRule())
+	write(w, http.StatusCreated, toGiteeRule(v), err)
+}
+
+func (s *Server) updateBranchProtectionRule(w http.ResponseWriter, r *http.Request, p params) {
+	var param branchProtectionRule
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	v, err := s.Fake.UpdateBranchProtectionRule(r.Context(), p["owner"], p["repo"], p["wildcard"], param.toRule())
+	write(w, http.StatusOK, toGiteeRule(v), err)
+}
+
+func (s *Server) deleteBranchProtectionRule(w http.ResponseWriter, r *http.Request, p params) {
+	err := s.Fake.DeleteBranchProtectionRule(r.Context(), p["owner"], p["repo"], p["wildcard"])
+	write(w, http.StatusNoContent, nil, err)
+}
@@ -0,0 +1,80 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestBranchProtectionRules(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddRepo("org", "repo")
+	if err := s.Fake.CreateBranch(context.Background(), "org", "repo", "release-1.0", "master"); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newClient(s)
+	ctx := context.Background()
+
+	rule := giteeclient.BranchProtectionRule{
+		Wildcard:    "release-*",
+		Pushers:     []string{giteeclient.ProtectionNone},
+		Mergers:     []string{giteeclient.ProtectionAdmin, "alice"},
+		ReviewCount: 2,
+	}
+
+	v, err := c.CreateBranchProtectionRule(ctx, "org", "repo", rule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ID == 0 || len(v.Mergers) != 2 || v.Mergers[1] != "alice" || v.ReviewCount != 2 {
+		t.Errorf("unexpected rule: %+v", v)
+	}
+
+	if _, err := c.CreateBranchProtectionRule(ctx, "org", "repo", rule); !giteeclient.IsUnprocessable(err) {
+		t.Errorf("expected 422 for the existing rule, got: %v", err)
+	}
+
+	branches, err := c.GetRepoAllBranch(ctx, "org", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range branches {
+		if b.Protected != (b.Name == "release-1.0") {
+			t.Errorf("unexpected protection of branch %s: %v", b.Name, b.Protected)
+		}
+	}
+
+	rule.Wildcard = "release/*"
+	rule.ReviewCount = 1
+	if _, err := c.UpdateBranchProtectionRule(ctx, "org", "repo", "release-*", rule); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetBranchProtectionRule(ctx, "org", "repo", "release-*"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected the old rule is renamed, got: %v", err)
+	}
+
+	v, err = c.GetBranchProtectionRule(ctx, "org", "repo", "release/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ReviewCount != 1 || v.Pushers[0] != giteeclient.ProtectionNone {
+		t.Errorf("unexpected rule: %+v", v)
+	}
+
+	if err := c.DeleteBranchProtectionRule(ctx, "org", "repo", "release/*"); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, err := c.ListBranchProtectionRules(ctx, "org", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 0 {
+		t.Errorf("expected no rules, got %+v", rules)
+	}
+}
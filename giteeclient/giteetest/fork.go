@@ -0,0 +1,25 @@
+package giteetest
+
+import "net/http"
+
+func (s *Server) registerForkRoutes() {
+	s.handle("GET", "/repos/:owner/:repo/forks", s.listForks)
+	s.handle("POST", "/repos/:owner/:repo/forks", s.forkRepo)
+}
+
+func (s *Server) listForks(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListForks(r.Context(), p["owner"], p["repo"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) forkRepo(w http.ResponseWriter, r *http.Request, p params) {
+	var param struct {
+		Organization string `json:"organization"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	v, err := s.Fake.ForkRepo(r.Context(), p["owner"], p["repo"], param.Organization)
+	write(w, http.StatusCreated, v, err)
+}
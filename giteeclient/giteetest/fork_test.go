@@ -0,0 +1,84 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestFork(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddFile("org", "repo", "master", "README.md", "hello")
+	c := newClient(s)
+	ctx := context.Background()
+
+	if _, err := c.GetBotFork(ctx, "org", "repo"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found before forking, got: %v", err)
+	}
+
+	v, err := c.ForkRepo(ctx, "org", "repo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !v.Fork || v.FullName != "bot/repo" || v.Parent == nil || v.Parent.FullName != "org/repo" {
+		t.Errorf("unexpected fork: %+v", v)
+	}
+
+	if _, err := c.ForkRepo(ctx, "org", "repo", ""); !giteeclient.IsUnprocessable(err) {
+		t.Errorf("expected 422 for forking again, got: %v", err)
+	}
+	if _, err := c.ForkRepo(ctx, "org", "repo", "community"); !giteeclient.IsForbidden(err) {
+		t.Errorf("expected 403 for an organization the bot isn't in, got: %v", err)
+	}
+
+	s.Fake.AddOrgMember("community", "bot")
+	if _, err := c.ForkRepo(ctx, "org", "repo", "community"); err != nil {
+		t.Fatal(err)
+	}
+
+	forks, err := c.ListForks(ctx, "org", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forks) != 2 || forks[0].FullName != "bot/repo" || forks[1].FullName != "community/repo" {
+		t.Errorf("unexpected forks: %+v", forks)
+	}
+
+	fork, err := c.GetBotFork(ctx, "org", "repo")
+	if err != nil || fork.FullName != "bot/repo" {
+		t.Errorf("unexpected bot fork: %+v, %v", fork, err)
+	}
+
+	wctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	if _, err := c.WaitForFork(wctx, "bot", "repo"); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := c.GetPathContent(ctx, "bot", "repo", "README.md", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Sha == "" {
+		t.Errorf("expected the files are forked, got: %+v", content)
+	}
+}
+
+func TestWaitForForkTimeout(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newClient(s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.WaitForFork(ctx, "bot", "missing"); err != context.DeadlineExceeded {
+		t.Errorf("expected the deadline exceeded, got: %v", err)
+	}
+}
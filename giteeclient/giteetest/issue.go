@@ -0,0 +1,187 @@
+package giteetest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func (s *Server) registerIssueRoutes() {
+	s.handle("POST", "/repos/:owner/issues", s.createIssue)
+	s.handle("GET", "/repos/:owner/:repo/issues", s.listIssues)
+	s.handle("PATCH", "/repos/:owner/issues/:number", s.updateIssue)
+	s.handle("PATCH", "/repos/:owner/:repo/issues/comments/:id", s.updateIssueComment)
+	s.handle("GET", "/repos/:owner/:repo/issues/:number", s.getIssue)
+	s.handle("GET", "/repos/:owner/:repo/issues/:number/comments", s.listIssueComments)
+	s.handle("POST", "/repos/:owner/:repo/issues/:number/comments", s.createIssueComment)
+	s.handle("GET", "/repos/:owner/:repo/issues/:number/labels", s.listIssueLabels)
+	s.handle("POST", "/repos/:owner/:repo/issues/:number/labels", s.addIssueLabels)
+	s.handle("DELETE", "/repos/:owner/:repo/issues/:number/labels/:name", s.removeIssueLabel)
+}
+
+func (s *Server) getIssue(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetIssue(r.Context(), p["owner"], p["repo"], p["number"])
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) createIssue(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.IssueCreateParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	if param.Repo == "" {
+		writeMessage(w, http.StatusBadRequest, "repo is missing")
+		return
+	}
+
+	opts := giteeclient.CreateIssueOpt{
+		Body:            param.Body,
+		Assignee:        param.Assignee,
+		MilestoneNumber: param.Milestone,
+		IssueType:       param.IssueType,
+	}
+	if param.Labels != "" {
+		opts.Labels = strings.Split(param.Labels, ",")
+	}
+
+	v, err := s.Fake.CreateIssue(r.Context(), p["owner"], param.Repo, param.Title, opts)
+	write(w, http.StatusCreated, v, err)
+}
+
+func (s *Server) listIssues(w http.ResponseWriter, r *http.Request, p params) {
+	q := r.URL.Query()
+
+	opt := giteeclient.ListIssueOpt{
+		State:     q.Get("state"),
+		Creator:   q.Get("creator"),
+		Assignee:  q.Get("assignee"),
+		Sort:      q.Get("sort"),
+		Direction: q.Get("direction"),
+	}
+	if v := q.Get("labels"); v != "" {
+		opt.Labels = strings.Split(v, ",")
+	}
+	if v := q.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			writeMessage(w, http.StatusBadRequest, "since is invalid")
+			return
+		}
+		opt.Since = t
+	}
+
+	v, err := s.Fake.ListIssues(r.Context(), p["owner"], p["repo"], opt)
+	writePage(w, r, v, err)
+}
+
+func (s *Server) updateIssue(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.IssueUpdateParam
+	var milestone struct {
+		Milestone *int32 `json:"milestone"`
+	}
+	if !decodeBody(w, r, &param, &milestone) {
+		return
+	}
+	param.AccessToken = ""
+
+	if param.Repo == "" {
+		writeMessage(w, http.StatusBadRequest, "repo is missing")
+		return
+	}
+
+	ctx := r.Context()
+	owner, number := p["owner"], p["number"]
+
+	// Map the request which only changes the milestone to the dedicated
+	// methods, so that Gitee's behaviors about milestone can be simulated.
+	if milestone.Milestone != nil && param == (sdk.IssueUpdateParam{Repo: param.Repo, Milestone: param.Milestone}) {
+		var err error
+		if param.Milestone == 0 {
+			err = s.Fake.ClearIssueMilestone(ctx, owner, param.Repo, number)
+		} else {
+			err = s.Fake.SetIssueMilestone(ctx, owner, param.Repo, number, param.Milestone)
+		}
+		if err != nil {
+			writeErr(w, err)
+			return
+		}
+
+		v, err := s.Fake.GetIssue(ctx, owner, param.Repo, number)
+		write(w, http.StatusOK, v, err)
+		return
+	}
+
+	v, err := s.Fake.UpdateIssue(ctx, owner, number, param)
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) listIssueComments(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListIssueComments(r.Context(), p["owner"], p["repo"], p["number"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) createIssueComment(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.IssueCommentPostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.Fake.CreateIssueComment(ctx, p["owner"], p["repo"], p["number"], param.Body); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v, err := s.Fake.ListIssueComments(ctx, p["owner"], p["repo"], p["number"])
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, v[len(v)-1])
+}
+
+func (s *Server) updateIssueComment(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := commentID(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.IssueCommentPatchParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	err := s.Fake.UpdateIssueComment(r.Context(), p["owner"], p["repo"], id, param.Body)
+	write(w, http.StatusOK, sdk.Note{Id: id, Body: param.Body}, err)
+}
+
+func (s *Server) listIssueLabels(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetIssueLabels(r.Context(), p["owner"], p["repo"], p["number"])
+	write(w, http.StatusOK, nonNil(v), err)
+}
+
+func (s *Server) addIssueLabels(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.PullRequestLabelPostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.Fake.AddMultiIssueLabel(ctx, p["owner"], p["repo"], p["number"], param.Body); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v, err := s.Fake.GetIssueLabels(ctx, p["owner"], p["repo"], p["number"])
+	write(w, http.StatusCreated, nonNil(v), err)
+}
+
+func (s *Server) removeIssueLabel(w http.ResponseWriter, r *http.Request, p params) {
+	err := s.Fake.RemoveIssueLabel(r.Context(), p["owner"], p["repo"], p["number"], p["name"])
+	write(w, http.StatusNoContent, nil, err)
+}
@@ -0,0 +1,84 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestCreateAndListIssues(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddCollaborator("org", "repo", "alice", "push")
+	milestone := s.Fake.AddMilestone("org", "repo", sdk.Milestone{Title: "v1.0"})
+	old := s.Fake.AddIssue("org", "repo", sdk.Issue{
+		Title:     "old",
+		CreatedAt: "2021-01-01T00:00:00Z",
+		UpdatedAt: "2021-01-01T00:00:00Z",
+	})
+	closed := s.Fake.AddIssue("org", "repo", sdk.Issue{Title: "closed", State: giteeclient.StatusClosed})
+
+	c := newClient(s)
+	ctx := context.Background()
+
+	issue, err := c.CreateIssue(ctx, "org", "repo", "bug", giteeclient.CreateIssueOpt{
+		Body:            "it crashes",
+		Labels:          []string{"kind/bug", "sig/infra"},
+		Assignee:        "alice",
+		MilestoneNumber: milestone,
+		IssueType:       "缺陷",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Assignee == nil || issue.Assignee.Login != "alice" || len(issue.Labels) != 2 ||
+		issue.Milestone == nil || issue.Milestone.Title != "v1.0" || issue.IssueType != "缺陷" {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+
+	_, err = c.CreateIssue(ctx, "org", "repo", "", giteeclient.CreateIssueOpt{})
+	if code := giteeclient.StatusCode(err); code != 400 {
+		t.Errorf("expected 400 for missing title, got: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		opt  giteeclient.ListIssueOpt
+		want []string
+	}{
+		{"open by default", giteeclient.ListIssueOpt{}, []string{issue.Number, old}},
+		{"all states in ascending order", giteeclient.ListIssueOpt{State: "all", Direction: "asc"}, []string{old, closed, issue.Number}},
+		{"labels", giteeclient.ListIssueOpt{Labels: []string{"kind/bug"}}, []string{issue.Number}},
+		{"assignee", giteeclient.ListIssueOpt{Assignee: "alice"}, []string{issue.Number}},
+		{"no assignee", giteeclient.ListIssueOpt{Assignee: "none"}, []string{old}},
+		{"creator", giteeclient.ListIssueOpt{Creator: "someone"}, nil},
+		{"since", giteeclient.ListIssueOpt{Since: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}, []string{issue.Number}},
+	}
+
+	for _, tc := range cases {
+		v, err := c.ListIssues(ctx, "org", "repo", tc.opt)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+
+		var got []string
+		for i := range v {
+			got = append(got, v[i].Number)
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package giteetest
+
+import "net/http"
+
+func (s *Server) registerMemberRoutes() {
+	s.handle("GET", "/orgs/:org/members", s.listOrgMembers)
+	s.handle("PUT", "/orgs/:org/memberships/:username", s.setOrgMember)
+	s.handle("DELETE", "/orgs/:org/memberships/:username", s.removeOrgMember)
+	s.handle("GET", "/enterprises/:enterprise/members", s.listEnterpriseMembers)
+	s.handle("GET", "/users/:username/orgs", s.listUserOrgs)
+	s.handle("GET", "/user/orgs", s.listBotOrgs)
+}
+
+func (s *Server) listOrgMembers(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListOrgMembers(r.Context(), p["org"], r.URL.Query().Get("role"))
+	writePage(w, r, v, err)
+}
+
+func (s *Server) setOrgMember(w http.ResponseWriter, r *http.Request, p params) {
+	var param struct {
+		Role string `json:"role"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	err := s.Fake.SetOrgMember(r.Context(), p["org"], p["username"], param.Role)
+	write(w, http.StatusOK, map[string]interface{}{"active": true, "role": param.Role}, err)
+}
+
+func (s *Server) removeOrgMember(w http.ResponseWriter, r *http.Request, p params) {
+	err := s.Fake.RemoveOrgMember(r.Context(), p["org"], p["username"])
+	write(w, http.StatusNoContent, nil, err)
+}
+
+func (s *Server) listEnterpriseMembers(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListEnterpriseMembers(r.Context(), p["enterprise"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) listUserOrgs(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListUserOrgs(r.Context(), p["username"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) listBotOrgs(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListBotOrgs(r.Context())
+	writePage(w, r, v, err)
+}
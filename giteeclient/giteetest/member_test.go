@@ -0,0 +1,118 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestOrgMembers(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddOrgMember("org", "alice")
+	c := newClient(s)
+	ctx := context.Background()
+
+	if err := c.SetOrgMember(ctx, "org", "bob", giteeclient.OrgRoleAdmin); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetOrgMember(ctx, "org", "carol", "owner"); giteeclient.StatusCode(err) != 400 {
+		t.Errorf("expected 400 for invalid role, got: %v", err)
+	}
+
+	all, err := c.ListOrgMembers(ctx, "org", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[0].Login != "bob" || all[0].Role != giteeclient.OrgRoleAdmin ||
+		all[1].Login != "alice" || all[1].Role != giteeclient.OrgRoleMember {
+		t.Errorf("unexpected members: %+v", all)
+	}
+
+	admins, err := c.ListOrgMembers(ctx, "org", giteeclient.OrgRoleAdmin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(admins) != 1 || admins[0].Login != "bob" {
+		t.Errorf("unexpected admins: %+v", admins)
+	}
+
+	if err := c.RemoveOrgMember(ctx, "org", "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if yes, err := c.IsMember(ctx, "org", "alice"); err != nil || yes {
+		t.Errorf("expected alice is removed, got: %v, %v", yes, err)
+	}
+	if err := c.RemoveOrgMember(ctx, "org", "alice"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found, got: %v", err)
+	}
+}
+
+func TestEnterpriseMembers(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	for _, login := range []string{"alice", "bob", "carol"} {
+		m := giteeclient.EnterpriseMember{Active: true}
+		m.User.Login = login
+		m.Role.Ident = "member"
+		s.Fake.AddEnterpriseMember("ent", m)
+	}
+
+	c := giteeclient.NewContextClient(
+		func() []byte { return []byte("token") },
+		giteeclient.ClientOptions{BaseURL: s.BaseURL(), PerPage: 2},
+	)
+	ctx := context.Background()
+
+	members, err := c.ListEnterpriseMembers(ctx, "ent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 3 || members[2].User.Login != "carol" {
+		t.Errorf("unexpected members: %+v", members)
+	}
+}
+
+func TestUserOrgs(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	for _, org := range []string{"org2", "org1"} {
+		s.Fake.AddOrgMember(org, "alice")
+	}
+	s.Fake.AddOrgMember("org3", "bot")
+
+	c := giteeclient.NewContextClient(
+		func() []byte { return []byte("token") },
+		giteeclient.ClientOptions{BaseURL: s.BaseURL(), PerPage: 1},
+	)
+	ctx := context.Background()
+
+	orgs, err := c.ListUserOrgs(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgs) != 2 || orgs[0].Login != "org1" || orgs[1].Login != "org2" {
+		t.Errorf("unexpected orgs: %+v", orgs)
+	}
+
+	// The groups are the orgs, whose members are listed as usual.
+	members, err := c.ListOrgMembers(ctx, orgs[0].Login, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 1 || members[0].Login != "alice" {
+		t.Errorf("unexpected members: %+v", members)
+	}
+
+	orgs, err = c.ListBotOrgs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orgs) != 1 || orgs[0].Login != "org3" {
+		t.Errorf("unexpected bot orgs: %+v", orgs)
+	}
+}
@@ -0,0 +1,61 @@
+package giteetest
+
+import (
+	"net/http"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
+
+func (s *Server) registerMilestoneRoutes() {
+	s.handle("GET", "/repos/:owner/:repo/milestones", s.listMilestones)
+	s.handle("POST", "/repos/:owner/:repo/milestones", s.createMilestone)
+	s.handle("GET", "/repos/:owner/:repo/milestones/:number", s.getMilestone)
+	s.handle("PATCH", "/repos/:owner/:repo/milestones/:number", s.updateMilestone)
+}
+
+func milestoneNumber(w http.ResponseWriter, p params) (int32, bool) {
+	n, ok := p.int32("number")
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found Milestone")
+	}
+	return n, ok
+}
+
+func (s *Server) listMilestones(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListMilestones(r.Context(), p["owner"], p["repo"], r.URL.Query().Get("state"))
+	writePage(w, r, v, err)
+}
+
+func (s *Server) getMilestone(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := milestoneNumber(w, p); ok {
+		v, err := s.Fake.GetMilestone(r.Context(), p["owner"], p["repo"], n)
+		write(w, http.StatusOK, v, err)
+	}
+}
+
+func (s *Server) createMilestone(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.MilestonePostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+	param.AccessToken = ""
+
+	v, err := s.Fake.CreateMilestone(r.Context(), p["owner"], p["repo"], param)
+	write(w, http.StatusCreated, v, err)
+}
+
+func (s *Server) updateMilestone(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := milestoneNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.MilestonePatchParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+	param.AccessToken = ""
+
+	v, err := s.Fake.UpdateMilestone(r.Context(), p["owner"], p["repo"], n, param)
+	write(w, http.StatusOK, v, err)
+}
@@ -0,0 +1,97 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestMilestones(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	prNumber := s.Fake.AddPullRequest("org", "repo", sdk.PullRequest{})
+	issueNumber := s.Fake.AddIssue("org", "repo", sdk.Issue{})
+	c := newClient(s)
+	ctx := context.Background()
+
+	m, err := c.CreateMilestone(ctx, "org", "repo", sdk.MilestonePostParam{Title: "v1.0", DueOn: "2022-01-01"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.CreateMilestone(ctx, "org", "repo", sdk.MilestonePostParam{Title: "v2.0"})
+	if code := giteeclient.StatusCode(err); code != 400 {
+		t.Errorf("expected 400 for missing due_on, got: %v", err)
+	}
+
+	if err := c.SetIssueMilestone(ctx, "org", "repo", issueNumber, m.Number); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetPRMilestone(ctx, "org", "repo", prNumber, m.Number); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetPRMilestone(ctx, "org", "repo", prNumber, m.Number+1); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found for missing milestone, got: %v", err)
+	}
+
+	issue, err := c.GetIssue(ctx, "org", "repo", issueNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Milestone == nil || issue.Milestone.Title != "v1.0" {
+		t.Errorf("expected milestone v1.0 of issue, got %+v", issue.Milestone)
+	}
+
+	// The milestone is cleared by sending a zero value which sdk omits.
+	if err := c.ClearIssueMilestone(ctx, "org", "repo", issueNumber); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.ClearPRMilestone(ctx, "org", "repo", prNumber); err != nil {
+		t.Fatal(err)
+	}
+
+	issue, err = c.GetIssue(ctx, "org", "repo", issueNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Milestone != nil {
+		t.Errorf("expected the milestone of issue is cleared, got %+v", issue.Milestone)
+	}
+
+	pr, err := c.GetGiteePullRequest(ctx, "org", "repo", prNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Milestone != nil {
+		t.Errorf("expected the milestone of pr is cleared, got %+v", pr.Milestone)
+	}
+
+	if err := c.CloseMilestone(ctx, "org", "repo", m.Number); err != nil {
+		t.Fatal(err)
+	}
+
+	open, err := c.ListMilestones(ctx, "org", "repo", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 0 {
+		t.Errorf("expected no open milestones, got %d", len(open))
+	}
+
+	all, err := c.ListMilestones(ctx, "org", "repo", "all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0].State != giteeclient.StatusClosed || all[0].DueOn != "2022-01-01" {
+		t.Errorf("expected the milestone is closed and kept due_on, got %+v", all)
+	}
+
+	actions := s.Fake.ActionsOf("ClearPRMilestone")
+	if len(actions) != 1 {
+		t.Errorf("expected 1 ClearPRMilestone action, got %d", len(actions))
+	}
+}
@@ -0,0 +1,155 @@
+package giteetest
+
+import (
+	"net/http"
+	"strings"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+// pullRequest is the pull request returned by Gitee whose reviewers and
+// testers have the field of accept.
+type pullRequest struct {
+	sdk.PullRequest
+
+	Assignees []giteeclient.PRReviewer `json:"assignees"`
+	Testers   []giteeclient.PRReviewer `json:"testers"`
+}
+
+func (s *Server) registerPRReviewRoutes() {
+	s.handle("PATCH", "/repos/:owner/:repo/pulls/:number/assignees", s.resetPRReview)
+	s.handle("POST", "/repos/:owner/:repo/pulls/:number/testers", s.assignPRTesters)
+	s.handle("DELETE", "/repos/:owner/:repo/pulls/:number/testers", s.unassignPRTesters)
+	s.handle("PATCH", "/repos/:owner/:repo/pulls/:number/testers", s.resetPRTest)
+	s.handle("POST", "/repos/:owner/:repo/pulls/:number/review", s.approvePRReview)
+	s.handle("POST", "/repos/:owner/:repo/pulls/:number/test", s.passPRTest)
+}
+
+func (s *Server) writePullRequest(w http.ResponseWriter, r *http.Request, p params, number int32, code int) {
+	ctx := r.Context()
+
+	pr, err := s.Fake.GetGiteePullRequest(ctx, p["owner"], p["repo"], number)
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	state, err := s.Fake.GetPRReviewState(ctx, p["owner"], p["repo"], number)
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	writeJSON(w, code, pullRequest{
+		PullRequest: pr,
+		Assignees:   state.Assignees,
+		Testers:     state.Testers,
+	})
+}
+
+func (s *Server) assignPRTesters(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param struct {
+		Testers string `json:"testers"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	logins := strings.Split(param.Testers, ",")
+	if err := s.Fake.AssignPRTesters(r.Context(), p["owner"], p["repo"], n, logins); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	s.writePullRequest(w, r, p, n, http.StatusCreated)
+}
+
+func (s *Server) unassignPRTesters(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	logins := strings.Split(r.URL.Query().Get("testers"), ",")
+	if err := s.Fake.UnassignPRTesters(r.Context(), p["owner"], p["repo"], n, logins); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	s.writePullRequest(w, r, p, n, http.StatusOK)
+}
+
+func (s *Server) approvePRReview(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param struct {
+		Force bool `json:"force"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	err := s.Fake.ApprovePRReview(r.Context(), p["owner"], p["repo"], n, param.Force)
+	write(w, http.StatusNoContent, nil, err)
+}
+
+func (s *Server) passPRTest(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param struct {
+		Force bool `json:"force"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	err := s.Fake.PassPRTest(r.Context(), p["owner"], p["repo"], n, param.Force)
+	write(w, http.StatusNoContent, nil, err)
+}
+
+func (s *Server) resetPRReview(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param struct {
+		ResetAll bool `json:"reset_all"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	err := s.Fake.ResetPRReview(r.Context(), p["owner"], p["repo"], n, param.ResetAll)
+	write(w, http.StatusNoContent, nil, err)
+}
+
+func (s *Server) resetPRTest(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param struct {
+		ResetAll bool `json:"reset_all"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	err := s.Fake.ResetPRTest(r.Context(), p["owner"], p["repo"], n, param.ResetAll)
+	write(w, http.StatusNoContent, nil, err)
+}
@@ -0,0 +1,79 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestPRReviewAndTest(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	n := s.Fake.AddPullRequest("org", "repo", sdk.PullRequest{AssigneesNumber: 1, TestersNumber: 1})
+	c := newClient(s)
+	ctx := context.Background()
+
+	if err := c.ApprovePRReview(ctx, "org", "repo", n, false); giteeclient.StatusCode(err) != 403 {
+		t.Errorf("expected 403 before the bot is assigned, got: %v", err)
+	}
+
+	if err := c.AssignPR(ctx, "org", "repo", n, []string{"bot", "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AssignPRTesters(ctx, "org", "repo", n, []string{"bot"}); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := c.GetPRReviewState(ctx, "org", "repo", n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.Assignees) != 2 || len(state.Testers) != 1 || state.ReviewApproved() || state.TestPassed() {
+		t.Errorf("unexpected state: %+v", state)
+	}
+
+	if err := c.ApprovePRReview(ctx, "org", "repo", n, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.PassPRTest(ctx, "org", "repo", n, false); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err = c.GetPRReviewState(ctx, "org", "repo", n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.ReviewApproved() || !state.TestPassed() {
+		t.Errorf("expected approved and passed, got: %+v", state)
+	}
+	if state.Assignees[0].Login != "bot" || !state.Assignees[0].Accept || state.Assignees[1].Accept {
+		t.Errorf("expected only the bot accepted, got: %+v", state.Assignees)
+	}
+
+	// Only the administrators can reset all.
+	if err := c.ResetPRReview(ctx, "org", "repo", n, true); giteeclient.StatusCode(err) != 403 {
+		t.Errorf("expected 403 for resetting all, got: %v", err)
+	}
+	if err := c.ResetPRReview(ctx, "org", "repo", n, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.UnassignPRTesters(ctx, "org", "repo", n, []string{"bot"}); err != nil {
+		t.Fatal(err)
+	}
+
+	state, err = c.GetPRReviewState(ctx, "org", "repo", n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ReviewApproved() || state.TestPassed() || len(state.Testers) != 0 {
+		t.Errorf("expected the review and test are reset, got: %+v", state)
+	}
+
+	if n := len(s.Fake.ActionsOf("ResetPRReview")); n != 1 {
+		t.Errorf("expected 1 ResetPRReview action, got %d", n)
+	}
+}
@@ -0,0 +1,329 @@
+package giteetest
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func (s *Server) registerPullRequestRoutes() {
+	s.handle("POST", "/repos/:owner/:repo/pulls", s.createPullRequest)
+	s.handle("GET", "/repos/:owner/:repo/pulls", s.listPullRequests)
+	s.handle("PATCH", "/repos/:owner/:repo/pulls/comments/:id", s.updatePRComment)
+	s.handle("DELETE", "/repos/:owner/:repo/pulls/comments/:id", s.deletePRComment)
+	s.handle("GET", "/repos/:owner/:repo/pulls/:number", s.getPullRequest)
+	s.handle("PATCH", "/repos/:owner/:repo/pulls/:number", s.updatePullRequest)
+	s.handle("GET", "/repos/:owner/:repo/pulls/:number/files", s.listPRFiles)
+	s.handle("GET", "/repos/:owner/:repo/pulls/:number/commits", s.listPRCommits)
+	s.handle("GET", "/repos/:owner/:repo/pulls/:number/operate_logs", s.listPROperationLogs)
+	s.handle("GET", "/repos/:owner/:repo/pulls/:number/issues", s.listPRIssues)
+	s.handle("GET", "/repos/:owner/:repo/pulls/:number/labels", s.listPRLabels)
+	s.handle("POST", "/repos/:owner/:repo/pulls/:number/labels", s.addPRLabels)
+	s.handle("PUT", "/repos/:owner/:repo/pulls/:number/labels", s.replacePRLabels)
+	s.handle("DELETE", "/repos/:owner/:repo/pulls/:number/labels/:name", s.removePRLabel)
+	s.handle("GET", "/repos/:owner/:repo/pulls/:number/comments", s.listPRComments)
+	s.handle("POST", "/repos/:owner/:repo/pulls/:number/comments", s.createPRComment)
+	s.handle("POST", "/repos/:owner/:repo/pulls/:number/assignees", s.assignPR)
+	s.handle("DELETE", "/repos/:owner/:repo/pulls/:number/assignees", s.unassignPR)
+	s.handle("PUT", "/repos/:owner/:repo/pulls/:number/merge", s.mergePR)
+}
+
+// prNumber returns the number of pr in the path and writes the not found
+// error if it is invalid.
+func prNumber(w http.ResponseWriter, p params) (int32, bool) {
+	n, ok := p.int32("number")
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found PullRequest")
+	}
+	return n, ok
+}
+
+func commentID(w http.ResponseWriter, p params) (int32, bool) {
+	n, ok := p.int32("id")
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found Comment")
+	}
+	return n, ok
+}
+
+func (s *Server) createPullRequest(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.CreatePullRequestParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	if param.Title == "" || param.Head == "" || param.Base == "" {
+		writeMessage(w, http.StatusBadRequest, "title, head and base are required")
+		return
+	}
+
+	pr, err := s.Fake.CreatePullRequest(
+		r.Context(), p["owner"], p["repo"], param.Title, param.Body,
+		param.Head, param.Base, false,
+	)
+	write(w, http.StatusCreated, pr, err)
+}
+
+func (s *Server) listPullRequests(w http.ResponseWriter, r *http.Request, p params) {
+	q := r.URL.Query()
+
+	opt := giteeclient.ListPullRequestOpt{
+		State:     q.Get("state"),
+		Head:      q.Get("head"),
+		Base:      q.Get("base"),
+		Sort:      q.Get("sort"),
+		Direction: q.Get("direction"),
+	}
+	if n, err := strconv.ParseInt(q.Get("milestone_number"), 10, 32); err == nil {
+		opt.MilestoneNumber = int32(n)
+	}
+	if v := q.Get("labels"); v != "" {
+		opt.Labels = strings.Split(v, ",")
+	}
+
+	prs, err := s.Fake.GetPullRequests(r.Context(), p["owner"], p["repo"], opt)
+	writePage(w, r, prs, err)
+}
+
+func (s *Server) getPullRequest(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := prNumber(w, p); ok {
+		s.writePullRequest(w, r, p, n, http.StatusOK)
+	}
+}
+
+func (s *Server) updatePullRequest(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.PullRequestUpdateParam
+	var milestone struct {
+		MilestoneNumber *int32 `json:"milestone_number"`
+	}
+	if !decodeBody(w, r, &param, &milestone) {
+		return
+	}
+	param.AccessToken = ""
+
+	ctx := r.Context()
+	owner, repo := p["owner"], p["repo"]
+
+	// See updateIssue for the requests which only change the milestone.
+	if milestone.MilestoneNumber != nil && param == (sdk.PullRequestUpdateParam{MilestoneNumber: param.MilestoneNumber}) {
+		var err error
+		if param.MilestoneNumber == 0 {
+			err = s.Fake.ClearPRMilestone(ctx, owner, repo, n)
+		} else {
+			err = s.Fake.SetPRMilestone(ctx, owner, repo, n, param.MilestoneNumber)
+		}
+		if err != nil {
+			writeErr(w, err)
+			return
+		}
+
+		pr, err := s.Fake.GetGiteePullRequest(ctx, owner, repo, n)
+		write(w, http.StatusOK, pr, err)
+		return
+	}
+
+	pr, err := s.Fake.UpdatePullRequest(ctx, owner, repo, n, param)
+	write(w, http.StatusOK, pr, err)
+}
+
+func (s *Server) listPRFiles(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := prNumber(w, p); ok {
+		v, err := s.Fake.GetPullRequestChanges(r.Context(), p["owner"], p["repo"], n)
+		write(w, http.StatusOK, nonNil(v), err)
+	}
+}
+
+func (s *Server) listPRCommits(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := prNumber(w, p); ok {
+		v, err := s.Fake.GetPRCommits(r.Context(), p["owner"], p["repo"], n)
+		write(w, http.StatusOK, nonNil(v), err)
+	}
+}
+
+func (s *Server) listPROperationLogs(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := prNumber(w, p); ok {
+		v, err := s.Fake.ListPROperationLogs(r.Context(), p["owner"], p["repo"], n)
+		write(w, http.StatusOK, nonNil(v), err)
+	}
+}
+
+func (s *Server) listPRIssues(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := prNumber(w, p); ok {
+		v, err := s.Fake.ListPrIssues(r.Context(), p["owner"], p["repo"], n)
+		writePage(w, r, v, err)
+	}
+}
+
+func (s *Server) listPRLabels(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := prNumber(w, p); ok {
+		v, err := s.Fake.GetPRLabels(r.Context(), p["owner"], p["repo"], n)
+		writePage(w, r, v, err)
+	}
+}
+
+func (s *Server) addPRLabels(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.PullRequestLabelPostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.Fake.AddMultiPRLabel(ctx, p["owner"], p["repo"], n, param.Body); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v, err := s.Fake.GetPRLabels(ctx, p["owner"], p["repo"], n)
+	write(w, http.StatusCreated, nonNil(v), err)
+}
+
+func (s *Server) replacePRLabels(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.PullRequestLabelPostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.Fake.ReplacePRAllLabels(ctx, p["owner"], p["repo"], n, param.Body); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v, err := s.Fake.GetPRLabels(ctx, p["owner"], p["repo"], n)
+	write(w, http.StatusOK, nonNil(v), err)
+}
+
+func (s *Server) removePRLabel(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := prNumber(w, p); ok {
+		err := s.Fake.RemovePRLabel(r.Context(), p["owner"], p["repo"], n, p["name"])
+		write(w, http.StatusNoContent, nil, err)
+	}
+}
+
+func (s *Server) listPRComments(w http.ResponseWriter, r *http.Request, p params) {
+	if n, ok := prNumber(w, p); ok {
+		v, err := s.Fake.ListPRComments(r.Context(), p["owner"], p["repo"], n)
+		writePage(w, r, v, err)
+	}
+}
+
+func (s *Server) createPRComment(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.PullRequestCommentPostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.Fake.CreatePRComment(ctx, p["owner"], p["repo"], n, param.Body); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v, err := s.Fake.ListPRComments(ctx, p["owner"], p["repo"], n)
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, v[len(v)-1])
+}
+
+func (s *Server) updatePRComment(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := commentID(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.PullRequestCommentPatchParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	err := s.Fake.UpdatePRComment(r.Context(), p["owner"], p["repo"], id, param.Body)
+	write(w, http.StatusOK, sdk.PullRequestComments{Id: id, Body: param.Body}, err)
+}
+
+func (s *Server) deletePRComment(w http.ResponseWriter, r *http.Request, p params) {
+	if id, ok := commentID(w, p); ok {
+		err := s.Fake.DeletePRComment(r.Context(), p["owner"], p["repo"], id)
+		write(w, http.StatusNoContent, nil, err)
+	}
+}
+
+func (s *Server) assignPR(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.PullRequestAssigneePostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	ctx := r.Context()
+	logins := strings.Split(param.Assignees, ",")
+	if err := s.Fake.AssignPR(ctx, p["owner"], p["repo"], n, logins); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	pr, err := s.Fake.GetGiteePullRequest(ctx, p["owner"], p["repo"], n)
+	write(w, http.StatusCreated, pr, err)
+}
+
+func (s *Server) unassignPR(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	logins := strings.Split(r.URL.Query().Get("assignees"), ",")
+	if err := s.Fake.UnassignPR(ctx, p["owner"], p["repo"], n, logins); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	pr, err := s.Fake.GetGiteePullRequest(ctx, p["owner"], p["repo"], n)
+	write(w, http.StatusOK, pr, err)
+}
+
+func (s *Server) mergePR(w http.ResponseWriter, r *http.Request, p params) {
+	n, ok := prNumber(w, p)
+	if !ok {
+		return
+	}
+
+	var param sdk.PullRequestMergePutParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+	param.AccessToken = ""
+
+	err := s.Fake.MergePR(r.Context(), p["owner"], p["repo"], n, param)
+	write(w, http.StatusOK, map[string]interface{}{"merged": true, "message": "Pull Request merged"}, err)
+}
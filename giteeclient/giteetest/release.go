@@ -0,0 +1,99 @@
+package giteetest
+
+import (
+	"net/http"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func (s *Server) registerReleaseRoutes() {
+	s.handle("GET", "/repos/:owner/:repo/releases", s.listReleases)
+	s.handle("POST", "/repos/:owner/:repo/releases", s.createRelease)
+	s.handle("GET", "/repos/:owner/:repo/releases/tags/:tag", s.getReleaseByTag)
+	s.handle("GET", "/repos/:owner/:repo/releases/:id", s.getRelease)
+	s.handle("PATCH", "/repos/:owner/:repo/releases/:id", s.updateRelease)
+	s.handle("DELETE", "/repos/:owner/:repo/releases/:id", s.deleteRelease)
+	s.handle("GET", "/repos/:owner/:repo/tags", s.listTags)
+	s.handle("POST", "/repos/:owner/:repo/tags", s.createTag)
+}
+
+func releaseID(w http.ResponseWriter, p params) (int32, bool) {
+	n, ok := p.int32("id")
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found Release")
+	}
+	return n, ok
+}
+
+func (s *Server) listReleases(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListReleases(r.Context(), p["owner"], p["repo"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) getRelease(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := releaseID(w, p)
+	if !ok {
+		return
+	}
+
+	v, err := s.Fake.GetRelease(r.Context(), p["owner"], p["repo"], id)
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) getReleaseByTag(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetReleaseByTag(r.Context(), p["owner"], p["repo"], p["tag"])
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) createRelease(w http.ResponseWriter, r *http.Request, p params) {
+	var param giteeclient.ReleaseParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	v, err := s.Fake.CreateRelease(r.Context(), p["owner"], p["repo"], param)
+	write(w, http.StatusCreated, v, err)
+}
+
+func (s *Server) updateRelease(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := releaseID(w, p)
+	if !ok {
+		return
+	}
+
+	var param giteeclient.ReleaseParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	v, err := s.Fake.UpdateRelease(r.Context(), p["owner"], p["repo"], id, param)
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) deleteRelease(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := releaseID(w, p)
+	if !ok {
+		return
+	}
+
+	err := s.Fake.DeleteRelease(r.Context(), p["owner"], p["repo"], id)
+	write(w, http.StatusNoContent, nil, err)
+}
+
+func (s *Server) listTags(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListTags(r.Context(), p["owner"], p["repo"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) createTag(w http.ResponseWriter, r *http.Request, p params) {
+	var param struct {
+		Refs    string `json:"refs"`
+		TagName string `json:"tag_name"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	v, err := s.Fake.CreateTag(r.Context(), p["owner"], p["repo"], param.TagName, param.Refs)
+	write(w, http.StatusCreated, v, err)
+}
@@ -0,0 +1,103 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestReleases(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddRepo("org", "repo")
+	c := newClient(s)
+	ctx := context.Background()
+
+	v, err := c.CreateRelease(ctx, "org", "repo", giteeclient.ReleaseParam{
+		TagName:         "v1.0.0",
+		Name:            "1.0.0",
+		Prerelease:      true,
+		TargetCommitish: "master",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.ID == 0 || !v.Prerelease || len(v.Assets) != 2 {
+		t.Errorf("unexpected release: %+v", v)
+	}
+
+	_, err = c.CreateRelease(ctx, "org", "repo", giteeclient.ReleaseParam{TagName: "v1.0.0", Name: "again"})
+	if !giteeclient.IsUnprocessable(err) {
+		t.Errorf("expected 422 for a tag released already, got: %v", err)
+	}
+
+	// Prerelease of false must be sent to turn the prerelease into a release.
+	v, err = c.UpdateRelease(ctx, "org", "repo", v.ID, giteeclient.ReleaseParam{
+		TagName: "v1.0.0", Name: "1.0.0", Body: "first release",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Prerelease || v.Body != "first release" {
+		t.Errorf("unexpected release after update: %+v", v)
+	}
+
+	got, err := c.GetReleaseByTag(ctx, "org", "repo", "v1.0.0")
+	if err != nil || got.ID != v.ID {
+		t.Errorf("unexpected release by tag: %+v, %v", got, err)
+	}
+
+	if err := c.DeleteRelease(ctx, "org", "repo", v.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetRelease(ctx, "org", "repo", v.ID); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found, got: %v", err)
+	}
+	if rs, err := c.ListReleases(ctx, "org", "repo"); err != nil || len(rs) != 0 {
+		t.Errorf("unexpected releases: %+v, %v", rs, err)
+	}
+}
+
+func TestTags(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddBranch("org", "repo", "stable")
+	c := newClient(s)
+	ctx := context.Background()
+
+	sha, err := c.GetRef(ctx, "org", "repo", "heads/stable")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tag, err := c.CreateTag(ctx, "org", "repo", "v0.1", "stable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != "v0.1" || tag.Commit.Sha != sha {
+		t.Errorf("unexpected tag: %+v", tag)
+	}
+
+	// The branch is resolved by the client, so the server receives the sha.
+	if a := s.Fake.ActionsOf("CreateTag"); len(a) != 1 || a[0].Args[3] != sha {
+		t.Errorf("unexpected actions: %+v", a)
+	}
+
+	if _, err := c.CreateTag(ctx, "org", "repo", "v0.1", sha); !giteeclient.IsUnprocessable(err) {
+		t.Errorf("expected 422 for an existing tag, got: %v", err)
+	}
+	if _, err := c.CreateTag(ctx, "org", "repo", "v0.2", "missing"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found, got: %v", err)
+	}
+
+	tags, err := c.ListTags(ctx, "org", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tags) != 1 || tags[0].Name != "v0.1" {
+		t.Errorf("unexpected tags: %+v", tags)
+	}
+}
@@ -0,0 +1,329 @@
+package giteetest
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
+
+func (s *Server) registerRepoRoutes() {
+	s.handle("GET", "/user", s.getUser)
+	s.handle("GET", "/orgs/:org/memberships/:username", s.getOrgMembership)
+	s.handle("GET", "/orgs/:org/repos", s.listOrgRepos)
+	s.handle("POST", "/orgs/:org/repos", s.createRepo)
+	s.handle("GET", "/repos/:owner/:repo", s.getRepo)
+	s.handle("PATCH", "/repos/:owner/:repo", s.updateRepo)
+	s.handle("PUT", "/repos/:owner/:repo/reviewer", s.setRepoReviewer)
+	s.handle("GET", "/repos/:owner/:repo/labels", s.listRepoLabels)
+	s.handle("POST", "/repos/:owner/:repo/labels", s.createRepoLabel)
+	s.handle("GET", "/repos/:owner/:repo/collaborators", s.listCollaborators)
+	s.handle("GET", "/repos/:owner/:repo/collaborators/:username", s.checkCollaborator)
+	s.handle("PUT", "/repos/:owner/:repo/collaborators/:username", s.addCollaborator)
+	s.handle("DELETE", "/repos/:owner/:repo/collaborators/:username", s.removeCollaborator)
+	s.handle("GET", "/repos/:owner/:repo/collaborators/:username/permission", s.getPermission)
+	s.handle("GET", "/repos/:owner/:repo/branches", s.listBranches)
+	s.handle("POST", "/repos/:owner/:repo/branches", s.createBranch)
+	s.handle("GET", "/repos/:owner/:repo/branches/:branch", s.getBranch)
+	s.handle("PUT", "/repos/:owner/:repo/branches/:branch/protection", s.setProtectionBranch)
+	s.handle("DELETE", "/repos/:owner/:repo/branches/:branch/protection", s.cancelProtectionBranch)
+	s.handle("GET", "/repos/:owner/:repo/commits/:sha", s.getCommit)
+	s.handle("GET", "/repos/:owner/:repo/contents/:path*", s.getContent)
+	s.handle("POST", "/repos/:owner/:repo/contents/:path*", s.createFile)
+	s.handle("GET", "/repos/:owner/:repo/git/trees/:sha", s.getTree)
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetBot(r.Context())
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) getOrgMembership(w http.ResponseWriter, r *http.Request, p params) {
+	yes, err := s.Fake.IsMember(r.Context(), p["org"], p["username"])
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	if !yes {
+		writeMessage(w, http.StatusNotFound, "Not Found User")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, sdk.GroupMember{
+		Active: true,
+		Role:   "member",
+		User:   &sdk.UserBasic{Login: p["username"], Name: p["username"]},
+	})
+}
+
+func (s *Server) listOrgRepos(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetRepos(r.Context(), p["org"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) createRepo(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.RepositoryPostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+	param.AccessToken = ""
+
+	if param.Name == "" {
+		writeMessage(w, http.StatusBadRequest, "name is missing")
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.Fake.CreateRepo(ctx, p["org"], param); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	repo := param.Path
+	if repo == "" {
+		repo = param.Name
+	}
+
+	v, err := s.Fake.GetGiteeRepo(ctx, p["org"], repo)
+	write(w, http.StatusCreated, v, err)
+}
+
+func (s *Server) getRepo(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetGiteeRepo(r.Context(), p["owner"], p["repo"])
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) updateRepo(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.RepoPatchParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+	param.AccessToken = ""
+
+	ctx := r.Context()
+	if err := s.Fake.UpdateRepo(ctx, p["owner"], p["repo"], param); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v, err := s.Fake.GetGiteeRepo(ctx, p["owner"], p["repo"])
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) setRepoReviewer(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.SetRepoReviewer
+	if !decodeBody(w, r, &param) {
+		return
+	}
+	param.AccessToken = ""
+
+	ctx := r.Context()
+	if err := s.Fake.SetRepoReviewer(ctx, p["owner"], p["repo"], param); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	v, err := s.Fake.GetGiteeRepo(ctx, p["owner"], p["repo"])
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) listRepoLabels(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetRepoLabels(r.Context(), p["owner"], p["repo"])
+	write(w, http.StatusOK, nonNil(v), err)
+}
+
+func (s *Server) createRepoLabel(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.LabelPostParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	if param.Name == "" || param.Color == "" {
+		writeMessage(w, http.StatusBadRequest, "name and color are required")
+		return
+	}
+
+	ctx := r.Context()
+	if err := s.Fake.CreateRepoLabel(ctx, p["owner"], p["repo"], param.Name, param.Color); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	labels, err := s.Fake.GetRepoLabels(ctx, p["owner"], p["repo"])
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	for _, l := range labels {
+		if l.Name == param.Name {
+			writeJSON(w, http.StatusCreated, l)
+			return
+		}
+	}
+}
+
+func (s *Server) listCollaborators(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListCollaborators(r.Context(), p["owner"], p["repo"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) checkCollaborator(w http.ResponseWriter, r *http.Request, p params) {
+	yes, err := s.Fake.IsCollaborator(r.Context(), p["owner"], p["repo"], p["username"])
+	switch {
+	case err != nil:
+		writeErr(w, err)
+	case !yes:
+		writeMessage(w, http.StatusNotFound, "Not Found User")
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (s *Server) addCollaborator(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.ProjectMemberPutParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	if param.Permission == "" {
+		param.Permission = "push"
+	}
+
+	err := s.Fake.AddRepoMember(r.Context(), p["owner"], p["repo"], p["username"], param.Permission)
+	write(w, http.StatusOK, sdk.ProjectMember{Login: p["username"], Name: p["username"]}, err)
+}
+
+func (s *Server) removeCollaborator(w http.ResponseWriter, r *http.Request, p params) {
+	ctx := r.Context()
+
+	yes, err := s.Fake.IsCollaborator(ctx, p["owner"], p["repo"], p["username"])
+	switch {
+	case err != nil:
+		writeErr(w, err)
+	case !yes:
+		writeMessage(w, http.StatusNotFound, "Not Found User")
+	default:
+		err := s.Fake.RemoveRepoMember(ctx, p["owner"], p["repo"], p["username"])
+		write(w, http.StatusNoContent, nil, err)
+	}
+}
+
+func (s *Server) getPermission(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetUserPermissionsOfRepo(r.Context(), p["owner"], p["repo"], p["username"])
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) listBranches(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetRepoAllBranch(r.Context(), p["owner"], p["repo"])
+	write(w, http.StatusOK, nonNil(v), err)
+}
+
+func (s *Server) findBranch(w http.ResponseWriter, r *http.Request, org, repo, name string) (sdk.CompleteBranch, bool) {
+	branches, err := s.Fake.GetRepoAllBranch(r.Context(), org, repo)
+	if err != nil {
+		writeErr(w, err)
+		return sdk.CompleteBranch{}, false
+	}
+
+	for _, b := range branches {
+		if b.Name == name {
+			return sdk.CompleteBranch{
+				Name:      b.Name,
+				Protected: b.Protected,
+				Commit:    &sdk.RepoCommit{Sha: b.Commit.Sha},
+			}, true
+		}
+	}
+
+	writeMessage(w, http.StatusNotFound, "Not Found Branch")
+	return sdk.CompleteBranch{}, false
+}
+
+func (s *Server) getBranch(w http.ResponseWriter, r *http.Request, p params) {
+	if b, ok := s.findBranch(w, r, p["owner"], p["repo"], p["branch"]); ok {
+		writeJSON(w, http.StatusOK, b)
+	}
+}
+
+func (s *Server) createBranch(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.CreateBranchParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	if param.BranchName == "" || param.Refs == "" {
+		writeMessage(w, http.StatusBadRequest, "branch_name and refs are required")
+		return
+	}
+
+	err := s.Fake.CreateBranch(r.Context(), p["owner"], p["repo"], param.BranchName, param.Refs)
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	if b, ok := s.findBranch(w, r, p["owner"], p["repo"], param.BranchName); ok {
+		writeJSON(w, http.StatusCreated, b)
+	}
+}
+
+func (s *Server) setProtectionBranch(w http.ResponseWriter, r *http.Request, p params) {
+	if err := s.Fake.SetProtectionBranch(r.Context(), p["owner"], p["repo"], p["branch"]); err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	if b, ok := s.findBranch(w, r, p["owner"], p["repo"], p["branch"]); ok {
+		writeJSON(w, http.StatusOK, b)
+	}
+}
+
+func (s *Server) cancelProtectionBranch(w http.ResponseWriter, r *http.Request, p params) {
+	err := s.Fake.CancelProtectionBranch(r.Context(), p["owner"], p["repo"], p["branch"])
+	write(w, http.StatusNoContent, nil, err)
+}
+
+func (s *Server) getCommit(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetPRCommit(r.Context(), p["owner"], p["repo"], p["sha"])
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) getContent(w http.ResponseWriter, r *http.Request, p params) {
+	ref := r.URL.Query().Get("ref")
+	v, err := s.Fake.GetPathContent(r.Context(), p["owner"], p["repo"], p["path"], ref)
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) createFile(w http.ResponseWriter, r *http.Request, p params) {
+	var param sdk.NewFileParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	if param.Message == "" {
+		writeMessage(w, http.StatusBadRequest, "message is missing")
+		return
+	}
+
+	content, err := base64.StdEncoding.DecodeString(param.Content)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, "content is not encoded in base64")
+		return
+	}
+
+	v, err := s.Fake.CreateFile(
+		r.Context(), p["owner"], p["repo"], param.Branch, p["path"],
+		string(content), param.Message,
+	)
+	write(w, http.StatusCreated, v, err)
+}
+
+func (s *Server) getTree(w http.ResponseWriter, r *http.Request, p params) {
+	recursive, _ := strconv.Atoi(r.URL.Query().Get("recursive"))
+
+	v, err := s.Fake.GetDirectoryTree(r.Context(), p["owner"], p["repo"], p["sha"], int32(recursive))
+	write(w, http.StatusOK, v, err)
+}
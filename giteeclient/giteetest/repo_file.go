@@ -0,0 +1,113 @@
+package giteetest
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func (s *Server) registerRepoFileRoutes() {
+	s.handle("PUT", "/repos/:owner/:repo/contents/:path*", s.updateFile)
+	s.handle("DELETE", "/repos/:owner/:repo/contents/:path*", s.deleteFile)
+	s.handle("POST", "/repos/:owner/:repo/commits", s.commitFiles)
+}
+
+func (s *Server) updateFile(w http.ResponseWriter, r *http.Request, p params) {
+	var param struct {
+		Content string `json:"content"`
+		SHA     string `json:"sha"`
+		Message string `json:"message"`
+		Branch  string `json:"branch"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	if param.Message == "" {
+		writeMessage(w, http.StatusBadRequest, "message is missing")
+		return
+	}
+
+	content, err := base64.StdEncoding.DecodeString(param.Content)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, "content is not encoded in base64")
+		return
+	}
+
+	v, err := s.Fake.UpdateFile(
+		r.Context(), p["owner"], p["repo"], param.Branch, p["path"],
+		string(content), param.SHA, param.Message,
+	)
+	writeFile(w, http.StatusOK, v, err)
+}
+
+func (s *Server) deleteFile(w http.ResponseWriter, r *http.Request, p params) {
+	q := r.URL.Query()
+
+	if q.Get("message") == "" {
+		writeMessage(w, http.StatusBadRequest, "message is missing")
+		return
+	}
+
+	v, err := s.Fake.DeleteFile(
+		r.Context(), p["owner"], p["repo"], q.Get("branch"), p["path"],
+		q.Get("sha"), q.Get("message"),
+	)
+	writeFile(w, http.StatusOK, v, err)
+}
+
+func (s *Server) commitFiles(w http.ResponseWriter, r *http.Request, p params) {
+	var param struct {
+		Branch  string `json:"branch"`
+		Message string `json:"message"`
+		Actions []struct {
+			Action       string `json:"action"`
+			Path         string `json:"path"`
+			PreviousPath string `json:"previous_path"`
+			Content      string `json:"content"`
+			Encoding     string `json:"encoding"`
+		} `json:"actions"`
+	}
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	changes := make([]giteeclient.FileChange, len(param.Actions))
+	for i, a := range param.Actions {
+		content := a.Content
+		if a.Encoding == "base64" {
+			v, err := base64.StdEncoding.DecodeString(a.Content)
+			if err != nil {
+				writeMessage(w, http.StatusBadRequest, "content is not encoded in base64")
+				return
+			}
+			content = string(v)
+		}
+
+		changes[i] = giteeclient.FileChange{
+			Action:       a.Action,
+			Path:         a.Path,
+			PreviousPath: a.PreviousPath,
+			Content:      content,
+		}
+	}
+
+	v, err := s.Fake.CommitFiles(r.Context(), p["owner"], p["repo"], param.Branch, param.Message, changes)
+	writeFile(w, http.StatusCreated, v, err)
+}
+
+// writeFile is the same as write, except that the conflict is written as a bad
+// request, since Gitee rejects the changes conflicting with the files by 400.
+func writeFile(w http.ResponseWriter, code int, v interface{}, err error) {
+	var e *giteeclient.Error
+	if errors.As(err, &e) && e.StatusCode == http.StatusConflict {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write(e.Body)
+		return
+	}
+
+	write(w, code, v, err)
+}
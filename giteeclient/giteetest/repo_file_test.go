@@ -0,0 +1,116 @@
+package giteetest
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestUpdateAndDeleteFile(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddFile("org", "repo", "master", "sig/OWNERS", "alice")
+	c := newClient(s)
+	ctx := context.Background()
+
+	v, err := c.GetPathContent(ctx, "org", "repo", "sig/OWNERS", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.UpdateFile(ctx, "org", "repo", "master", "sig/OWNERS", "alice\nbob", v.Sha, "add bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The sha read before is stale now.
+	_, err = c.UpdateFile(ctx, "org", "repo", "master", "sig/OWNERS", "carol", v.Sha, "replace")
+	if !giteeclient.IsConflict(err) {
+		t.Errorf("expected conflict, got: %v", err)
+	}
+	if _, err := c.DeleteFile(ctx, "org", "repo", "master", "sig/OWNERS", v.Sha, "delete"); !giteeclient.IsConflict(err) {
+		t.Errorf("expected conflict, got: %v", err)
+	}
+
+	// A bad request is not taken as a conflict.
+	_, err = c.UpdateFile(ctx, "org", "repo", "master", "sig/OWNERS", "carol", "", "replace")
+	if giteeclient.StatusCode(err) != http.StatusBadRequest {
+		t.Errorf("expected bad request, got: %v", err)
+	}
+
+	v, err = c.GetPathContent(ctx, "org", "repo", "sig/OWNERS", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := base64.StdEncoding.DecodeString(v.Content); string(b) != "alice\nbob" {
+		t.Errorf("unexpected content: %q", b)
+	}
+
+	if _, err := c.DeleteFile(ctx, "org", "repo", "master", "sig/OWNERS", v.Sha, "delete"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetPathContent(ctx, "org", "repo", "sig/OWNERS", "master"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found, got: %v", err)
+	}
+}
+
+func TestCommitFiles(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddFile("org", "repo", "master", "a.txt", "a")
+	s.Fake.AddFile("org", "repo", "master", "b.txt", "b")
+	c := newClient(s)
+	ctx := context.Background()
+
+	// None of the changes is applied if one of them fails.
+	_, err := c.CommitFiles(ctx, "org", "repo", "master", "update", []giteeclient.FileChange{
+		{Action: giteeclient.FileActionDelete, Path: "a.txt"},
+		{Action: giteeclient.FileActionCreate, Path: "b.txt", Content: "new"},
+	})
+	if !giteeclient.IsConflict(err) {
+		t.Errorf("expected conflict, got: %v", err)
+	}
+	if _, err := c.GetPathContent(ctx, "org", "repo", "a.txt", "master"); err != nil {
+		t.Errorf("expected a.txt is kept, got: %v", err)
+	}
+
+	commit, err := c.CommitFiles(ctx, "org", "repo", "master", "update", []giteeclient.FileChange{
+		{Action: giteeclient.FileActionDelete, Path: "a.txt"},
+		{Action: giteeclient.FileActionUpdate, Path: "b.txt", Content: ""},
+		{Action: giteeclient.FileActionMove, Path: "docs/b.txt", PreviousPath: "b.txt"},
+		{Action: giteeclient.FileActionCreate, Path: "c.txt", Content: "c"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sha, err := c.GetRef(ctx, "org", "repo", "heads/master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commit.Sha != sha {
+		t.Errorf("expected the branch points to %s, got %s", commit.Sha, sha)
+	}
+
+	tree, err := c.GetDirectoryTree(ctx, "org", "repo", "master", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]int32{}
+	for _, item := range tree.Tree {
+		if item.Type_ == "blob" {
+			files[item.Path] = item.Size
+		}
+	}
+	if len(files) != 2 || files["c.txt"] != 1 {
+		t.Errorf("unexpected files: %v", files)
+	}
+	if size, ok := files["docs/b.txt"]; !ok || size != 0 {
+		t.Errorf("expected docs/b.txt is emptied, got: %v", files)
+	}
+}
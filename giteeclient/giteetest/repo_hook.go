@@ -0,0 +1,67 @@
+package giteetest
+
+import (
+	"net/http"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func (s *Server) registerRepoHookRoutes() {
+	s.handle("GET", "/repos/:owner/:repo/hooks", s.listRepoHooks)
+	s.handle("POST", "/repos/:owner/:repo/hooks", s.createRepoHook)
+	s.handle("PATCH", "/repos/:owner/:repo/hooks/:id", s.updateRepoHook)
+	s.handle("DELETE", "/repos/:owner/:repo/hooks/:id", s.deleteRepoHook)
+	s.handle("POST", "/repos/:owner/:repo/hooks/:id/tests", s.testRepoHook)
+}
+
+func hookID(w http.ResponseWriter, p params) (int32, bool) {
+	n, ok := p.int32("id")
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found Hook")
+	}
+	return n, ok
+}
+
+func (s *Server) listRepoHooks(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListRepoHooks(r.Context(), p["owner"], p["repo"])
+	writePage(w, r, v, err)
+}
+
+func (s *Server) createRepoHook(w http.ResponseWriter, r *http.Request, p params) {
+	var param giteeclient.HookParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	v, err := s.Fake.CreateRepoHook(r.Context(), p["owner"], p["repo"], param)
+	write(w, http.StatusCreated, v, err)
+}
+
+func (s *Server) updateRepoHook(w http.ResponseWriter, r *http.Request, p params) {
+	id, ok := hookID(w, p)
+	if !ok {
+		return
+	}
+
+	var param giteeclient.HookParam
+	if !decodeBody(w, r, &param) {
+		return
+	}
+
+	v, err := s.Fake.UpdateRepoHook(r.Context(), p["owner"], p["repo"], id, param)
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) deleteRepoHook(w http.ResponseWriter, r *http.Request, p params) {
+	if id, ok := hookID(w, p); ok {
+		err := s.Fake.DeleteRepoHook(r.Context(), p["owner"], p["repo"], id)
+		write(w, http.StatusNoContent, nil, err)
+	}
+}
+
+func (s *Server) testRepoHook(w http.ResponseWriter, r *http.Request, p params) {
+	if id, ok := hookID(w, p); ok {
+		err := s.Fake.TestRepoHook(r.Context(), p["owner"], p["repo"], id)
+		write(w, http.StatusNoContent, nil, err)
+	}
+}
@@ -0,0 +1,73 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestRepoHooks(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddRepo("org", "repo")
+	c := newClient(s)
+	ctx := context.Background()
+
+	param := giteeclient.HookParam{
+		HookEvents: giteeclient.HookEvents{
+			MergeRequestsEvents: true,
+			NoteEvents:          true,
+		},
+		URL:            "https://robot.example.com/gitee-hook",
+		Password:       "secret",
+		EncryptionType: giteeclient.HookEncryptionSignKey,
+	}
+
+	h, err := c.CreateRepoHook(ctx, "org", "repo", param)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.CreateRepoHook(ctx, "org", "repo", giteeclient.HookParam{}); giteeclient.StatusCode(err) != 400 {
+		t.Errorf("expected 400 for missing url, got: %v", err)
+	}
+
+	// Disabling an event must be sent explicitly.
+	param.NoteEvents = false
+	param.Password = ""
+	if _, err := c.UpdateRepoHook(ctx, "org", "repo", h.ID, param); err != nil {
+		t.Fatal(err)
+	}
+
+	hooks, err := c.ListRepoHooks(ctx, "org", "repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 1 {
+		t.Fatalf("expected 1 hook, got %d", len(hooks))
+	}
+
+	v := hooks[0]
+	if !v.MergeRequestsEvents || v.NoteEvents || v.PushEvents {
+		t.Errorf("unexpected events: %+v", v.HookEvents)
+	}
+	if v.EncryptionType != giteeclient.HookEncryptionSignKey || v.Password != "secret" {
+		t.Errorf("expected the signing key is kept, got %+v", v)
+	}
+
+	if err := c.TestRepoHook(ctx, "org", "repo", h.ID); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.Fake.ActionsOf("TestRepoHook")); n != 1 {
+		t.Errorf("expected 1 TestRepoHook action, got %d", n)
+	}
+
+	if err := c.DeleteRepoHook(ctx, "org", "repo", h.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteRepoHook(ctx, "org", "repo", h.ID); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found, got: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package giteetest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func (s *Server) registerSearchRoutes() {
+	s.handle("GET", "/search/repositories", s.searchRepos)
+	s.handle("GET", "/search/issues", s.searchIssues)
+	s.handle("GET", "/search/users", s.searchUsers)
+}
+
+func (s *Server) searchRepos(w http.ResponseWriter, r *http.Request, p params) {
+	q := r.URL.Query()
+	fork, _ := strconv.ParseBool(q.Get("fork"))
+
+	v, err := s.Fake.SearchRepos(r.Context(), q.Get("q"), giteeclient.SearchRepoOpt{
+		Owner:    q.Get("owner"),
+		Fork:     fork,
+		Language: q.Get("language"),
+		Sort:     q.Get("sort"),
+		Order:    q.Get("order"),
+	})
+	writePage(w, r, v, err)
+}
+
+func (s *Server) searchIssues(w http.ResponseWriter, r *http.Request, p params) {
+	q := r.URL.Query()
+
+	v, err := s.Fake.SearchIssues(r.Context(), q.Get("q"), giteeclient.SearchIssueOpt{
+		Repo:     q.Get("repo"),
+		Language: q.Get("language"),
+		Label:    q.Get("label"),
+		State:    q.Get("state"),
+		Author:   q.Get("author"),
+		Assignee: q.Get("assignee"),
+		Sort:     q.Get("sort"),
+		Order:    q.Get("order"),
+	})
+	writePage(w, r, v, err)
+}
+
+func (s *Server) searchUsers(w http.ResponseWriter, r *http.Request, p params) {
+	q := r.URL.Query()
+
+	v, err := s.Fake.SearchUsers(r.Context(), q.Get("q"), giteeclient.SearchUserOpt{
+		Sort:  q.Get("sort"),
+		Order: q.Get("order"),
+	})
+	writePage(w, r, v, err)
+}
@@ -0,0 +1,84 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestSearch(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddProject("org", sdk.Project{Path: "robot-gitee", Description: "the robot"})
+	s.Fake.AddProject("org", sdk.Project{Path: "docs"})
+	s.Fake.AddProject("other", sdk.Project{Path: "robot-fork", Fork: true})
+
+	s.Fake.AddIssue("org", "docs", sdk.Issue{
+		Title:  "robot crashes",
+		User:   &sdk.UserBasic{Login: "alice"},
+		Labels: []sdk.Label{{Name: "bug"}},
+	})
+	s.Fake.AddIssue("org", "docs", sdk.Issue{Title: "Robot docs", User: &sdk.UserBasic{Login: "bob"}})
+	s.Fake.AddIssue("org", "robot-gitee", sdk.Issue{Title: "typo", Body: "in the robot"})
+
+	s.Fake.AddUser(sdk.User{Login: "robot-helper"})
+
+	// A small page makes the client fetch more than one page.
+	c := giteeclient.NewContextClient(
+		func() []byte { return []byte("token") },
+		giteeclient.ClientOptions{BaseURL: s.BaseURL(), PerPage: 1},
+	)
+	ctx := context.Background()
+
+	repos, err := c.SearchRepos(ctx, "robot", giteeclient.SearchRepoOpt{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 1 || repos[0].Path != "robot-gitee" {
+		t.Errorf("unexpected repos: %+v", repos)
+	}
+
+	repos, err = c.SearchRepos(ctx, "robot", giteeclient.SearchRepoOpt{Fork: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repos) != 2 {
+		t.Errorf("expected the fork is included, got: %+v", repos)
+	}
+
+	issues, err := c.SearchIssues(ctx, "robot", giteeclient.SearchIssueOpt{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 {
+		t.Errorf("unexpected issues: %+v", issues)
+	}
+
+	issues, err = c.SearchIssues(ctx, "robot", giteeclient.SearchIssueOpt{Repo: "org/docs", Label: "bug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].User.Login != "alice" {
+		t.Errorf("unexpected issues with label: %+v", issues)
+	}
+
+	issues, err = c.SearchIssues(ctx, "robot", giteeclient.SearchIssueOpt{Author: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Title != "Robot docs" {
+		t.Errorf("unexpected issues of author: %+v", issues)
+	}
+
+	users, err := c.SearchUsers(ctx, "bot", giteeclient.SearchUserOpt{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].Login != "bot" || users[1].Login != "robot-helper" {
+		t.Errorf("unexpected users: %+v", users)
+	}
+}
@@ -0,0 +1,279 @@
+// Package giteetest provides a local stand-in of Gitee API v5 for the
+// integration tests of giteeclient and plugins. It serves the endpoints
+// used by giteeclient over HTTP, so the real client including pagination
+// and error formatting can be tested without network access.
+package giteetest
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+	"github.com/opensourceways/community-robot-lib/giteeclient/fake"
+)
+
+const (
+	apiPrefix = "/api/v5"
+
+	defaultPerPage = 20
+	maxPerPage     = 100
+
+	headerTotalCount = "total_count"
+	headerTotalPage  = "total_page"
+)
+
+type params map[string]string
+
+func (p params) int32(k string) (int32, bool) {
+	v, err := strconv.ParseInt(p[k], 10, 32)
+	return int32(v), err == nil
+}
+
+type handler func(w http.ResponseWriter, r *http.Request, p params)
+
+type route struct {
+	method  string
+	pattern []string
+	handle  handler
+}
+
+// Server is a Gitee API v5 stand-in backed by a fake.Client.
+type Server struct {
+	*httptest.Server
+
+	// Fake holds the data served. Use it to seed the data before the test
+	// and check the actions after it. The actions are recorded with the
+	// methods of fake.Client which the requests are mapped to.
+	Fake *fake.Client
+
+	routes []route
+}
+
+// NewServer starts a server backed by an empty fake.Client. The caller should
+// call Close when finished to shut it down.
+func NewServer() *Server {
+	s := &Server{Fake: fake.NewClient()}
+
+	s.registerPullRequestRoutes()
+	s.registerPRReviewRoutes()
+	s.registerIssueRoutes()
+	s.registerMilestoneRoutes()
+	// It must be before the routes of repo, otherwise /branches/setting
+	// is matched by the route to get a branch.
+	s.registerBranchProtectionRoutes()
+	s.registerRepoRoutes()
+	s.registerRepoHookRoutes()
+	s.registerRepoFileRoutes()
+	s.registerMemberRoutes()
+	s.registerReleaseRoutes()
+	s.registerUserRoutes()
+	s.registerSearchRoutes()
+	s.registerForkRoutes()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL returns the address which can be set as giteeclient.ClientOptions.BaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/api"
+}
+
+// handle registers h for the method and the pattern, such as
+// /repos/:owner/:repo/pulls/:number. The last segment of the pattern
+// can be a wildcard like :path* which matches the rest of the path.
+func (s *Server) handle(method, pattern string, h handler) {
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handle:  h,
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.EscapedPath()
+	if !strings.HasPrefix(path, apiPrefix+"/") {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	if r.Method != http.MethodGet && !hasToken(r) {
+		writeMessage(w, http.StatusUnauthorized, "401 Unauthorized: Access token does not exist")
+		return
+	}
+
+	segs := strings.Split(strings.Trim(strings.TrimPrefix(path, apiPrefix), "/"), "/")
+	for i := range segs {
+		v, err := url.PathUnescape(segs[i])
+		if err != nil {
+			writeMessage(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		segs[i] = v
+	}
+
+	for _, rt := range s.routes {
+		if rt.method != r.Method {
+			continue
+		}
+
+		if p, ok := match(rt.pattern, segs); ok {
+			rt.handle(w, r, p)
+			return
+		}
+	}
+
+	writeMessage(w, http.StatusNotFound, "Not Found")
+}
+
+func match(pattern, segs []string) (params, bool) {
+	p := params{}
+	for i, v := range pattern {
+		if strings.HasPrefix(v, ":") && strings.HasSuffix(v, "*") {
+			if i >= len(segs) {
+				return nil, false
+			}
+			p[strings.Trim(v, ":*")] = strings.Join(segs[i:], "/")
+			return p, true
+		}
+
+		if i >= len(segs) {
+			return nil, false
+		}
+
+		if strings.HasPrefix(v, ":") {
+			p[v[1:]] = segs[i]
+		} else if v != segs[i] {
+			return nil, false
+		}
+	}
+	return p, len(pattern) == len(segs)
+}
+
+func hasToken(r *http.Request) bool {
+	if r.URL.Query().Get("access_token") != "" {
+		return true
+	}
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") != ""
+}
+
+// decodeBody decodes the body into every v, so that a handler can tell the
+// fields which are absent from the ones of zero value.
+func decodeBody(w http.ResponseWriter, r *http.Request, v ...interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err == nil {
+		for _, item := range v {
+			if err = json.Unmarshal(body, item); err != nil {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	var body []byte
+	if v != nil {
+		var err error
+		if body, err = json.Marshal(v); err != nil {
+			code = http.StatusInternalServerError
+			body = []byte(`{"message":"Internal Server Error"}`)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// writeMessage writes the error body in the same format as Gitee.
+func writeMessage(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]string{"message": msg})
+}
+
+// writeErr writes the error returned by the fake client. The status code
+// and the body of giteeclient.Error are kept as they are.
+func writeErr(w http.ResponseWriter, err error) {
+	var e *giteeclient.Error
+	if !errors.As(err, &e) {
+		writeMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.StatusCode)
+	_, _ = w.Write(e.Body)
+}
+
+// write writes v with code if err is nil, otherwise writes err.
+func write(w http.ResponseWriter, code int, v interface{}, err error) {
+	if err != nil {
+		writeErr(w, err)
+	} else {
+		writeJSON(w, code, v)
+	}
+}
+
+// writePage writes the page of items requested by the page and per_page
+// parameters. Same as Gitee, the page is empty if it is beyond the last one,
+// and the total count and total page are set in the headers.
+func writePage(w http.ResponseWriter, r *http.Request, items interface{}, err error) {
+	if err != nil {
+		writeErr(w, err)
+		return
+	}
+
+	q := r.URL.Query()
+
+	page, err := strconv.Atoi(q.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(q.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	v := reflect.ValueOf(items)
+	total := v.Len()
+
+	start := (page - 1) * perPage
+	if start > total {
+		start = total
+	}
+	end := start + perPage
+	if end > total {
+		end = total
+	}
+
+	w.Header().Set(headerTotalCount, strconv.Itoa(total))
+	w.Header().Set(headerTotalPage, strconv.Itoa((total+perPage-1)/perPage))
+
+	result := reflect.MakeSlice(v.Type(), 0, end-start)
+	result = reflect.AppendSlice(result, v.Slice(start, end))
+	writeJSON(w, http.StatusOK, result.Interface())
+}
+
+// nonNil returns an empty slice if items is nil, so that it is written as [].
+func nonNil(items interface{}) interface{} {
+	if v := reflect.ValueOf(items); v.IsNil() {
+		return reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+	return items
+}
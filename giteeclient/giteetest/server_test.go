@@ -0,0 +1,155 @@
+package giteetest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func newClient(s *Server) giteeclient.ContextClient {
+	return giteeclient.NewContextClient(
+		func() []byte { return []byte("token") },
+		giteeclient.ClientOptions{BaseURL: s.BaseURL()},
+	)
+}
+
+func TestPagination(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	number := s.Fake.AddPullRequest("org", "repo", sdk.PullRequest{})
+	c := giteeclient.NewContextClient(
+		func() []byte { return []byte("token") },
+		giteeclient.ClientOptions{BaseURL: s.BaseURL(), PerPage: defaultPerPage},
+	)
+	ctx := context.Background()
+
+	n := defaultPerPage*2 + 5
+	for i := 0; i < n; i++ {
+		if err := c.CreatePRComment(ctx, "org", "repo", number, fmt.Sprintf("comment %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	comments, err := c.ListPRComments(ctx, "org", "repo", number)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(comments) != n {
+		t.Fatalf("expected %d comments, got %d", n, len(comments))
+	}
+
+	if comments[n-1].Body != fmt.Sprintf("comment %d", n-1) {
+		t.Errorf("unexpected order of comments, the last one is %q", comments[n-1].Body)
+	}
+
+	count := 0
+	err = c.IteratePRComments(ctx, "org", "repo", number, func(sdk.PullRequestComments) error {
+		if count++; count == defaultPerPage+1 {
+			return giteeclient.ErrStopPagination
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != defaultPerPage+1 {
+		t.Errorf("expected to stop at the %dth comment, got %d", defaultPerPage+1, count)
+	}
+
+	resp, err := http.Get(s.BaseURL() + "/v5/repos/org/repo/pulls/1/comments?page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if v := resp.Header.Get(headerTotalCount); v != fmt.Sprint(n) {
+		t.Errorf("expected total count %d, got %s", n, v)
+	}
+	if v := resp.Header.Get(headerTotalPage); v != "3" {
+		t.Errorf("expected total page 3, got %s", v)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddRepo("org", "repo")
+	c := newClient(s)
+	ctx := context.Background()
+
+	_, err := c.GetGiteePullRequest(ctx, "org", "repo", 1)
+	if !giteeclient.IsNotFound(err) {
+		t.Fatalf("expected not found, got: %v", err)
+	}
+
+	e, ok := err.(*giteeclient.Error)
+	if !ok {
+		t.Fatalf("expected *giteeclient.Error, got: %T", err)
+	}
+	if string(e.Body) != `{"message":"Not Found PullRequest"}` {
+		t.Errorf("unexpected error body: %s", e.Body)
+	}
+
+	if err := c.CreateRepoLabel(ctx, "org", "repo", "bug", "ffffff"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CreateRepoLabel(ctx, "org", "repo", "bug", "ffffff"); !giteeclient.IsUnprocessable(err) {
+		t.Errorf("expected unprocessable, got: %v", err)
+	}
+
+	ok, err = c.IsCollaborator(ctx, "org", "repo", "someone")
+	if err != nil || ok {
+		t.Errorf("expected not a collaborator, got: %t, %v", ok, err)
+	}
+}
+
+func TestLabelsAndFiles(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	number := s.Fake.AddPullRequest("org", "repo", sdk.PullRequest{})
+	c := newClient(s)
+	ctx := context.Background()
+
+	if err := c.AddMultiPRLabel(ctx, "org", "repo", number, []string{"kind/bug", "lgtm"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.RemovePRLabel(ctx, "org", "repo", number, "kind/bug"); err != nil {
+		t.Fatal(err)
+	}
+
+	labels, err := c.GetPRLabels(ctx, "org", "repo", number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 1 || labels[0].Name != "lgtm" {
+		t.Errorf("unexpected labels: %v", labels)
+	}
+
+	if _, err := c.CreateFile(ctx, "org", "repo", "master", "docs/README.md", "hello", "add readme"); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := c.GetPathContent(ctx, "org", "repo", "docs/README.md", "master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.Content != "aGVsbG8=" {
+		t.Errorf("unexpected content: %s", content.Content)
+	}
+
+	sha, err := c.GetRef(ctx, "org", "repo", "heads/master")
+	if err != nil || sha == "" {
+		t.Errorf("failed to get ref, sha: %s, err: %v", sha, err)
+	}
+}
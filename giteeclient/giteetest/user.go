@@ -0,0 +1,18 @@
+package giteetest
+
+import "net/http"
+
+func (s *Server) registerUserRoutes() {
+	s.handle("GET", "/users/:username", s.getUserByLogin)
+	s.handle("GET", "/emails", s.listBotEmails)
+}
+
+func (s *Server) getUserByLogin(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.GetUser(r.Context(), p["username"])
+	write(w, http.StatusOK, v, err)
+}
+
+func (s *Server) listBotEmails(w http.ResponseWriter, r *http.Request, p params) {
+	v, err := s.Fake.ListBotEmails(r.Context())
+	write(w, http.StatusOK, nonNil(v), err)
+}
@@ -0,0 +1,91 @@
+package giteetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+)
+
+func TestGetUser(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddUser(sdk.User{Login: "alice", Email: "alice@example.com"})
+	s.Fake.AddUser(sdk.User{Login: "bob"})
+	c := newClient(s)
+	ctx := context.Background()
+
+	u, err := c.GetUser(ctx, "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Login != "alice" || u.Id == 0 {
+		t.Errorf("unexpected user: %+v", u)
+	}
+
+	if email, err := c.GetUserEmail(ctx, "alice"); err != nil || email != "alice@example.com" {
+		t.Errorf("unexpected email of alice: %q, %v", email, err)
+	}
+	if email, err := c.GetUserEmail(ctx, "bob"); err != nil || email != "" {
+		t.Errorf("expected no public email of bob, got: %q, %v", email, err)
+	}
+	if _, err := c.GetUser(ctx, "carol"); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found, got: %v", err)
+	}
+}
+
+func TestGetUserCache(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	s.Fake.AddUser(sdk.User{Login: "alice", Email: "old@example.com"})
+
+	c := giteeclient.NewContextClient(
+		func() []byte { return []byte("token") },
+		giteeclient.ClientOptions{BaseURL: s.BaseURL(), UserCacheTTL: 50 * time.Millisecond},
+	)
+	ctx := context.Background()
+
+	if _, err := c.GetUser(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.Fake.AddUser(sdk.User{Login: "alice", Email: "new@example.com"})
+
+	// The login is case-insensitive, so the cached user is hit.
+	if email, err := c.GetUserEmail(ctx, "Alice"); err != nil || email != "old@example.com" {
+		t.Errorf("expected the cached email, got: %q, %v", email, err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	if email, err := c.GetUserEmail(ctx, "alice"); err != nil || email != "new@example.com" {
+		t.Errorf("expected the email after expiration, got: %q, %v", email, err)
+	}
+}
+
+func TestListBotEmails(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	c := newClient(s)
+	ctx := context.Background()
+
+	if v, err := c.ListBotEmails(ctx); err != nil || len(v) != 0 {
+		t.Errorf("expected no emails, got: %+v, %v", v, err)
+	}
+
+	s.Fake.SetBot(sdk.User{Id: 1, Login: "bot", Email: "bot@example.com"})
+
+	v, err := c.ListBotEmails(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v) != 1 || v[0].Email != "bot@example.com" {
+		t.Errorf("unexpected emails: %+v", v)
+	}
+}
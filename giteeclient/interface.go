@@ -1,6 +1,11 @@
 package giteeclient
 
-import sdk "gitee.com/openeuler/go-gitee/gitee"
+import (
+	"context"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
 
 // Client interface for Gitee API
 type Client interface {
@@ -9,15 +14,25 @@ type Client interface {
 	UpdatePullRequest(org, repo string, number int32, param sdk.PullRequestUpdateParam) (sdk.PullRequest, error)
 
 	ListCollaborators(org, repo string) ([]sdk.ProjectMember, error)
+	IterateCollaborators(org, repo string, handle func(sdk.ProjectMember) error) error
 	IsCollaborator(owner, repo, login string) (bool, error)
 	IsMember(org, login string) (bool, error)
 	RemoveRepoMember(org, repo, login string) error
 	AddRepoMember(org, repo, login, permission string) error
 
+	ListOrgMembers(org, role string) ([]OrgMember, error)
+	SetOrgMember(org, login, role string) error
+	RemoveOrgMember(org, login string) error
+	ListEnterpriseMembers(enterprise string) ([]EnterpriseMember, error)
+	ListUserOrgs(login string) ([]sdk.Group, error)
+	ListBotOrgs() ([]sdk.Group, error)
+
 	GetRef(org, repo, ref string) (string, error)
 	GetPullRequestChanges(org, repo string, number int32) ([]sdk.PullRequestFiles, error)
 	GetPRLabels(org, repo string, number int32) ([]sdk.Label, error)
+	IteratePRLabels(org, repo string, number int32, handle func(sdk.Label) error) error
 	ListPRComments(org, repo string, number int32) ([]sdk.PullRequestComments, error)
+	IteratePRComments(org, repo string, number int32, handle func(sdk.PullRequestComments) error) error
 	ListPrIssues(org, repo string, number int32) ([]sdk.Issue, error)
 	DeletePRComment(org, repo string, ID int32) error
 	CreatePRComment(org, repo string, number int32, comment string) error
@@ -34,11 +49,20 @@ type Client interface {
 	UnassignPR(owner, repo string, number int32, logins []string) error
 	GetPRCommits(org, repo string, number int32) ([]sdk.PullRequestCommits, error)
 
+	AssignPRTesters(org, repo string, number int32, logins []string) error
+	UnassignPRTesters(org, repo string, number int32, logins []string) error
+	ApprovePRReview(org, repo string, number int32, force bool) error
+	PassPRTest(org, repo string, number int32, force bool) error
+	ResetPRReview(org, repo string, number int32, resetAll bool) error
+	ResetPRTest(org, repo string, number int32, resetAll bool) error
+	GetPRReviewState(org, repo string, number int32) (PRReviewState, error)
+
 	GetGiteePullRequest(org, repo string, number int32) (sdk.PullRequest, error)
 	GetPRCommit(org, repo, SHA string) (sdk.RepoCommit, error)
 	MergePR(owner, repo string, number int32, opt sdk.PullRequestMergePutParam) error
 
 	GetRepos(org string) ([]sdk.Project, error)
+	IterateRepos(org string, handle func(sdk.Project) error) error
 	CreateRepo(org string, repo sdk.RepositoryPostParam) error
 	UpdateRepo(org, repo string, info sdk.RepoPatchParam) error
 	GetRepo(org, repo string) (sdk.Project, error)
@@ -48,11 +72,18 @@ type Client interface {
 	CreateRepoLabel(org, repo, label, color string) error
 	GetRepoLabels(owner, repo string) ([]sdk.Label, error)
 
+	ListRepoHooks(org, repo string) ([]Hook, error)
+	CreateRepoHook(org, repo string, param HookParam) (Hook, error)
+	UpdateRepoHook(org, repo string, id int32, param HookParam) (Hook, error)
+	TestRepoHook(org, repo string, id int32) error
+	DeleteRepoHook(org, repo string, id int32) error
+
 	AssignGiteeIssue(org, repo string, number string, login string) error
 	UnassignGiteeIssue(org, repo string, number string, login string) error
 	CreateIssueComment(org, repo string, number string, comment string) error
 	UpdateIssueComment(org, repo string, commentID int32, comment string) error
 	ListIssueComments(org, repo, number string) ([]sdk.Note, error)
+	IterateIssueComments(org, repo, number string, handle func(sdk.Note) error) error
 	GetIssueLabels(org, repo, number string) ([]sdk.Label, error)
 	RemoveIssueLabel(org, repo, number, label string) error
 	RemoveIssueLabels(org, repo, number string, label []string) error
@@ -63,17 +94,208 @@ type Client interface {
 	UpdateIssue(owner, number string, param sdk.IssueUpdateParam) (sdk.Issue, error)
 	GetIssue(org, repo, number string) (sdk.Issue, error)
 
+	CreateIssue(org, repo, title string, opts CreateIssueOpt) (sdk.Issue, error)
+	ListIssues(org, repo string, opts ListIssueOpt) ([]sdk.Issue, error)
+
+	ListMilestones(org, repo, state string) ([]sdk.Milestone, error)
+	GetMilestone(org, repo string, number int32) (sdk.Milestone, error)
+	CreateMilestone(org, repo string, param sdk.MilestonePostParam) (sdk.Milestone, error)
+	UpdateMilestone(org, repo string, number int32, param sdk.MilestonePatchParam) (sdk.Milestone, error)
+	CloseMilestone(org, repo string, number int32) error
+	SetIssueMilestone(org, repo, number string, milestone int32) error
+	ClearIssueMilestone(org, repo, number string) error
+	SetPRMilestone(org, repo string, number, milestone int32) error
+	ClearPRMilestone(org, repo string, number int32) error
+
 	CreateBranch(org, repo, branch, parentBranch string) error
 	GetRepoAllBranch(org, repo string) ([]sdk.Branch, error)
 	SetProtectionBranch(org, repo, branch string) error
 	CancelProtectionBranch(org, repo, branch string) error
 
+	ListBranchProtectionRules(org, repo string) ([]BranchProtectionRule, error)
+	GetBranchProtectionRule(org, repo, wildcard string) (BranchProtectionRule, error)
+	CreateBranchProtectionRule(org, repo string, rule BranchProtectionRule) (BranchProtectionRule, error)
+	UpdateBranchProtectionRule(org, repo, wildcard string, rule BranchProtectionRule) (BranchProtectionRule, error)
+	DeleteBranchProtectionRule(org, repo, wildcard string) error
+
+	ListReleases(org, repo string) ([]Release, error)
+	GetRelease(org, repo string, id int32) (Release, error)
+	GetReleaseByTag(org, repo, tag string) (Release, error)
+	CreateRelease(org, repo string, param ReleaseParam) (Release, error)
+	UpdateRelease(org, repo string, id int32, param ReleaseParam) (Release, error)
+	DeleteRelease(org, repo string, id int32) error
+	ListTags(org, repo string) ([]Tag, error)
+	CreateTag(org, repo, tag, ref string) (Tag, error)
+
 	CreateFile(org, repo, branch, path, content, commitMsg string) (sdk.CommitContent, error)
 	GetPathContent(org, repo, path, ref string) (sdk.Content, error)
 	GetDirectoryTree(org, repo, sha string, recursive int32) (sdk.Tree, error)
 
+	UpdateFile(org, repo, branch, path, content, sha, commitMsg string) (sdk.CommitContent, error)
+	DeleteFile(org, repo, branch, path, sha, commitMsg string) (sdk.CommitContent, error)
+	CommitFiles(org, repo, branch, commitMsg string, changes []FileChange) (sdk.RepoCommit, error)
+
 	GetBot() (sdk.User, error)
 	GetUserPermissionsOfRepo(org, repo, login string) (sdk.ProjectMemberPermission, error)
+
+	GetUser(login string) (sdk.User, error)
+	GetUserEmail(login string) (string, error)
+	ListBotEmails() ([]UserEmail, error)
+
+	SearchRepos(q string, opts SearchRepoOpt) ([]sdk.Project, error)
+	SearchIssues(q string, opts SearchIssueOpt) ([]sdk.Issue, error)
+	SearchUsers(q string, opts SearchUserOpt) ([]sdk.User, error)
+
+	ForkRepo(org, repo, namespace string) (sdk.Project, error)
+	ListForks(org, repo string) ([]sdk.Project, error)
+	GetBotFork(org, repo string) (sdk.Project, error)
+	WaitForFork(org, repo string) (sdk.Project, error)
+}
+
+// ContextClient is the same as Client except that every method accepts a
+// context.Context, which can be used to cancel the request or set a deadline.
+type ContextClient interface {
+	CreatePullRequest(ctx context.Context, org, repo, title, body, head, base string, canModify bool) (sdk.PullRequest, error)
+	GetPullRequests(ctx context.Context, org, repo string, opts ListPullRequestOpt) ([]sdk.PullRequest, error)
+	UpdatePullRequest(ctx context.Context, org, repo string, number int32, param sdk.PullRequestUpdateParam) (sdk.PullRequest, error)
+
+	ListCollaborators(ctx context.Context, org, repo string) ([]sdk.ProjectMember, error)
+	IterateCollaborators(ctx context.Context, org, repo string, handle func(sdk.ProjectMember) error) error
+	IsCollaborator(ctx context.Context, owner, repo, login string) (bool, error)
+	IsMember(ctx context.Context, org, login string) (bool, error)
+	RemoveRepoMember(ctx context.Context, org, repo, login string) error
+	AddRepoMember(ctx context.Context, org, repo, login, permission string) error
+
+	ListOrgMembers(ctx context.Context, org, role string) ([]OrgMember, error)
+	SetOrgMember(ctx context.Context, org, login, role string) error
+	RemoveOrgMember(ctx context.Context, org, login string) error
+	ListEnterpriseMembers(ctx context.Context, enterprise string) ([]EnterpriseMember, error)
+	ListUserOrgs(ctx context.Context, login string) ([]sdk.Group, error)
+	ListBotOrgs(ctx context.Context) ([]sdk.Group, error)
+
+	GetRef(ctx context.Context, org, repo, ref string) (string, error)
+	GetPullRequestChanges(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestFiles, error)
+	GetPRLabels(ctx context.Context, org, repo string, number int32) ([]sdk.Label, error)
+	IteratePRLabels(ctx context.Context, org, repo string, number int32, handle func(sdk.Label) error) error
+	ListPRComments(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestComments, error)
+	IteratePRComments(ctx context.Context, org, repo string, number int32, handle func(sdk.PullRequestComments) error) error
+	ListPrIssues(ctx context.Context, org, repo string, number int32) ([]sdk.Issue, error)
+	DeletePRComment(ctx context.Context, org, repo string, ID int32) error
+	CreatePRComment(ctx context.Context, org, repo string, number int32, comment string) error
+	UpdatePRComment(ctx context.Context, org, repo string, commentID int32, comment string) error
+	AddPRLabel(ctx context.Context, org, repo string, number int32, label string) error
+	AddMultiPRLabel(ctx context.Context, org, repo string, number int32, label []string) error
+	RemovePRLabel(ctx context.Context, org, repo string, number int32, label string) error
+	RemovePRLabels(ctx context.Context, org, repo string, number int32, labels []string) error
+	ReplacePRAllLabels(ctx context.Context, owner, repo string, number int32, labels []string) error
+	ListPROperationLogs(ctx context.Context, org, repo string, number int32) ([]sdk.OperateLog, error)
+
+	ClosePR(ctx context.Context, org, repo string, number int32) error
+	AssignPR(ctx context.Context, owner, repo string, number int32, logins []string) error
+	UnassignPR(ctx context.Context, owner, repo string, number int32, logins []string) error
+	GetPRCommits(ctx context.Context, org, repo string, number int32) ([]sdk.PullRequestCommits, error)
+
+	AssignPRTesters(ctx context.Context, org, repo string, number int32, logins []string) error
+	UnassignPRTesters(ctx context.Context, org, repo string, number int32, logins []string) error
+	ApprovePRReview(ctx context.Context, org, repo string, number int32, force bool) error
+	PassPRTest(ctx context.Context, org, repo string, number int32, force bool) error
+	ResetPRReview(ctx context.Context, org, repo string, number int32, resetAll bool) error
+	ResetPRTest(ctx context.Context, org, repo string, number int32, resetAll bool) error
+	GetPRReviewState(ctx context.Context, org, repo string, number int32) (PRReviewState, error)
+
+	GetGiteePullRequest(ctx context.Context, org, repo string, number int32) (sdk.PullRequest, error)
+	GetPRCommit(ctx context.Context, org, repo, SHA string) (sdk.RepoCommit, error)
+	MergePR(ctx context.Context, owner, repo string, number int32, opt sdk.PullRequestMergePutParam) error
+
+	GetRepos(ctx context.Context, org string) ([]sdk.Project, error)
+	IterateRepos(ctx context.Context, org string, handle func(sdk.Project) error) error
+	CreateRepo(ctx context.Context, org string, repo sdk.RepositoryPostParam) error
+	UpdateRepo(ctx context.Context, org, repo string, info sdk.RepoPatchParam) error
+	GetRepo(ctx context.Context, org, repo string) (sdk.Project, error)
+	GetGiteeRepo(ctx context.Context, org, repo string) (sdk.Project, error)
+
+	SetRepoReviewer(ctx context.Context, org, repo string, reviewer sdk.SetRepoReviewer) error
+	CreateRepoLabel(ctx context.Context, org, repo, label, color string) error
+	GetRepoLabels(ctx context.Context, owner, repo string) ([]sdk.Label, error)
+
+	ListRepoHooks(ctx context.Context, org, repo string) ([]Hook, error)
+	CreateRepoHook(ctx context.Context, org, repo string, param HookParam) (Hook, error)
+	UpdateRepoHook(ctx context.Context, org, repo string, id int32, param HookParam) (Hook, error)
+	TestRepoHook(ctx context.Context, org, repo string, id int32) error
+	DeleteRepoHook(ctx context.Context, org, repo string, id int32) error
+
+	AssignGiteeIssue(ctx context.Context, org, repo string, number string, login string) error
+	UnassignGiteeIssue(ctx context.Context, org, repo string, number string, login string) error
+	CreateIssueComment(ctx context.Context, org, repo string, number string, comment string) error
+	UpdateIssueComment(ctx context.Context, org, repo string, commentID int32, comment string) error
+	ListIssueComments(ctx context.Context, org, repo, number string) ([]sdk.Note, error)
+	IterateIssueComments(ctx context.Context, org, repo, number string, handle func(sdk.Note) error) error
+	GetIssueLabels(ctx context.Context, org, repo, number string) ([]sdk.Label, error)
+	RemoveIssueLabel(ctx context.Context, org, repo, number, label string) error
+	RemoveIssueLabels(ctx context.Context, org, repo, number string, label []string) error
+	AddIssueLabel(ctx context.Context, org, repo, number, label string) error
+	AddMultiIssueLabel(ctx context.Context, org, repo, number string, label []string) error
+	CloseIssue(ctx context.Context, owner, repo string, number string) error
+	ReopenIssue(ctx context.Context, owner, repo string, number string) error
+	UpdateIssue(ctx context.Context, owner, number string, param sdk.IssueUpdateParam) (sdk.Issue, error)
+	GetIssue(ctx context.Context, org, repo, number string) (sdk.Issue, error)
+
+	CreateIssue(ctx context.Context, org, repo, title string, opts CreateIssueOpt) (sdk.Issue, error)
+	ListIssues(ctx context.Context, org, repo string, opts ListIssueOpt) ([]sdk.Issue, error)
+
+	ListMilestones(ctx context.Context, org, repo, state string) ([]sdk.Milestone, error)
+	GetMilestone(ctx context.Context, org, repo string, number int32) (sdk.Milestone, error)
+	CreateMilestone(ctx context.Context, org, repo string, param sdk.MilestonePostParam) (sdk.Milestone, error)
+	UpdateMilestone(ctx context.Context, org, repo string, number int32, param sdk.MilestonePatchParam) (sdk.Milestone, error)
+	CloseMilestone(ctx context.Context, org, repo string, number int32) error
+	SetIssueMilestone(ctx context.Context, org, repo, number string, milestone int32) error
+	ClearIssueMilestone(ctx context.Context, org, repo, number string) error
+	SetPRMilestone(ctx context.Context, org, repo string, number, milestone int32) error
+	ClearPRMilestone(ctx context.Context, org, repo string, number int32) error
+
+	CreateBranch(ctx context.Context, org, repo, branch, parentBranch string) error
+	GetRepoAllBranch(ctx context.Context, org, repo string) ([]sdk.Branch, error)
+	SetProtectionBranch(ctx context.Context, org, repo, branch string) error
+	CancelProtectionBranch(ctx context.Context, org, repo, branch string) error
+
+	ListBranchProtectionRules(ctx context.Context, org, repo string) ([]BranchProtectionRule, error)
+	GetBranchProtectionRule(ctx context.Context, org, repo, wildcard string) (BranchProtectionRule, error)
+	CreateBranchProtectionRule(ctx context.Context, org, repo string, rule BranchProtectionRule) (BranchProtectionRule, error)
+	UpdateBranchProtectionRule(ctx context.Context, org, repo, wildcard string, rule BranchProtectionRule) (BranchProtectionRule, error)
+	DeleteBranchProtectionRule(ctx context.Context, org, repo, wildcard string) error
+
+	ListReleases(ctx context.Context, org, repo string) ([]Release, error)
+	GetRelease(ctx context.Context, org, repo string, id int32) (Release, error)
+	GetReleaseByTag(ctx context.Context, org, repo, tag string) (Release, error)
+	CreateRelease(ctx context.Context, org, repo string, param ReleaseParam) (Release, error)
+	UpdateRelease(ctx context.Context, org, repo string, id int32, param ReleaseParam) (Release, error)
+	DeleteRelease(ctx context.Context, org, repo string, id int32) error
+	ListTags(ctx context.Context, org, repo string) ([]Tag, error)
+	CreateTag(ctx context.Context, org, repo, tag, ref string) (Tag, error)
+
+	CreateFile(ctx context.Context, org, repo, branch, path, content, commitMsg string) (sdk.CommitContent, error)
+	GetPathContent(ctx context.Context, org, repo, path, ref string) (sdk.Content, error)
+	GetDirectoryTree(ctx context.Context, org, repo, sha string, recursive int32) (sdk.Tree, error)
+
+	UpdateFile(ctx context.Context, org, repo, branch, path, content, sha, commitMsg string) (sdk.CommitContent, error)
+	DeleteFile(ctx context.Context, org, repo, branch, path, sha, commitMsg string) (sdk.CommitContent, error)
+	CommitFiles(ctx context.Context, org, repo, branch, commitMsg string, changes []FileChange) (sdk.RepoCommit, error)
+
+	GetBot(ctx context.Context) (sdk.User, error)
+	GetUserPermissionsOfRepo(ctx context.Context, org, repo, login string) (sdk.ProjectMemberPermission, error)
+
+	GetUser(ctx context.Context, login string) (sdk.User, error)
+	GetUserEmail(ctx context.Context, login string) (string, error)
+	ListBotEmails(ctx context.Context) ([]UserEmail, error)
+
+	SearchRepos(ctx context.Context, q string, opts SearchRepoOpt) ([]sdk.Project, error)
+	SearchIssues(ctx context.Context, q string, opts SearchIssueOpt) ([]sdk.Issue, error)
+	SearchUsers(ctx context.Context, q string, opts SearchUserOpt) ([]sdk.User, error)
+
+	ForkRepo(ctx context.Context, org, repo, namespace string) (sdk.Project, error)
+	ListForks(ctx context.Context, org, repo string) ([]sdk.Project, error)
+	GetBotFork(ctx context.Context, org, repo string) (sdk.Project, error)
+	WaitForFork(ctx context.Context, org, repo string) (sdk.Project, error)
 }
 
 type ListPullRequestOpt struct {
@@ -85,3 +307,55 @@ type ListPullRequestOpt struct {
 	MilestoneNumber int32
 	Labels          []string
 }
+
+type CreateIssueOpt struct {
+	Body            string
+	Labels          []string
+	Assignee        string
+	MilestoneNumber int32
+	IssueType       string
+}
+
+// ListIssueOpt filters the issues to list. The issues updated before Since
+// are skipped unless it is zero.
+type ListIssueOpt struct {
+	State     string
+	Labels    []string
+	Creator   string
+	Assignee  string
+	Since     time.Time
+	Sort      string
+	Direction string
+}
+
+// SearchRepoOpt filters the repositories to search. The forks are
+// skipped unless Fork is true. Sort is one of "stars_count",
+// "forks_count" and "last_push_at", and Order is "asc" or "desc".
+type SearchRepoOpt struct {
+	Owner    string
+	Fork     bool
+	Language string
+	Sort     string
+	Order    string
+}
+
+// SearchIssueOpt filters the issues to search. Repo is in the format of
+// "owner/repo". Sort is one of "created_at", "updated_at" and "notes_count",
+// and Order is "asc" or "desc".
+type SearchIssueOpt struct {
+	Repo     string
+	Language string
+	Label    string
+	State    string
+	Author   string
+	Assignee string
+	Sort     string
+	Order    string
+}
+
+// SearchUserOpt sorts the users to search. Sort is "joined_at",
+// and Order is "asc" or "desc".
+type SearchUserOpt struct {
+	Sort  string
+	Order string
+}
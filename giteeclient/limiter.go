@@ -0,0 +1,168 @@
+package giteeclient
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+// LimiterOptions holds the options to create a Limiter.
+type LimiterOptions struct {
+	// MaxInFlight is the maximum number of requests in flight. Zero means no limit.
+	MaxInFlight int
+
+	// QPS is the maximum number of requests sent per second. Zero means no limit.
+	QPS float64
+
+	// Burst is the size of token bucket. The ceiling of QPS will be used if it is zero.
+	Burst int
+}
+
+// Limiter bounds the number of requests in flight and the rate of requests.
+// A request waits in the queue until it gets a slot and a token, or until its
+// context is done. A request is in flight until its response header is received.
+//
+// It can be shared by the clients which use the same token, since Gitee throttles
+// the requests by token. It implements prometheus.Collector to report the queue depth.
+type Limiter struct {
+	slots   chan struct{}
+	limiter *rate.Limiter
+
+	waiting  int64
+	inFlight int64
+
+	waitingGauge  prometheus.Gauge
+	inFlightGauge prometheus.Gauge
+	waitDuration  prometheus.Histogram
+}
+
+// NewLimiter creates a Limiter which should be set to ClientOptions.Limiter.
+func NewLimiter(opts LimiterOptions) *Limiter {
+	l := &Limiter{
+		waitingGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "limiter_waiting_requests",
+			Help:      "The number of requests waiting for the limiter.",
+		}),
+		inFlightGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "limiter_in_flight_requests",
+			Help:      "The number of requests in flight allowed by the limiter.",
+		}),
+		waitDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "limiter_wait_duration_seconds",
+			Help:      "The time requests waited for the limiter.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+	}
+
+	if opts.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, opts.MaxInFlight)
+	}
+
+	if opts.QPS > 0 {
+		burst := opts.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(opts.QPS))
+		}
+		l.limiter = rate.NewLimiter(rate.Limit(opts.QPS), burst)
+	}
+
+	return l
+}
+
+// Waiting returns the number of requests in the queue.
+func (l *Limiter) Waiting() int {
+	return int(atomic.LoadInt64(&l.waiting))
+}
+
+// InFlight returns the number of requests in flight.
+func (l *Limiter) InFlight() int {
+	return int(atomic.LoadInt64(&l.inFlight))
+}
+
+// Describe implements prometheus.Collector.
+func (l *Limiter) Describe(ch chan<- *prometheus.Desc) {
+	l.waitingGauge.Describe(ch)
+	l.inFlightGauge.Describe(ch)
+	l.waitDuration.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (l *Limiter) Collect(ch chan<- prometheus.Metric) {
+	l.waitingGauge.Collect(ch)
+	l.inFlightGauge.Collect(ch)
+	l.waitDuration.Collect(ch)
+}
+
+// acquire waits for a slot and then a token, so that the tokens are not
+// consumed by the requests which can't be sent yet. The returned function
+// releases the slot, and it must be called once the request is done.
+func (l *Limiter) acquire(ctx context.Context) (func(), error) {
+	start := time.Now()
+	l.addWaiting(1)
+	defer l.addWaiting(-1)
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			if l.slots != nil {
+				<-l.slots
+			}
+			return nil, err
+		}
+	}
+
+	l.waitDuration.Observe(time.Since(start).Seconds())
+	l.addInFlight(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.addInFlight(-1)
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}, nil
+}
+
+func (l *Limiter) addWaiting(n int64) {
+	atomic.AddInt64(&l.waiting, n)
+	l.waitingGauge.Add(float64(n))
+}
+
+func (l *Limiter) addInFlight(n int64) {
+	atomic.AddInt64(&l.inFlight, n)
+	l.inFlightGauge.Add(float64(n))
+}
+
+// limiterTransport sends the request after it is allowed by the limiter.
+type limiterTransport struct {
+	limiter *Limiter
+	base    http.RoundTripper
+}
+
+func (t *limiterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, err := t.limiter.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,120 @@
+package giteeclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestLimiterBoundsInFlight(t *testing.T) {
+	var current, peak int64
+	unblock := make(chan struct{})
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&current, 1)
+		defer atomic.AddInt64(&current, -1)
+
+		for {
+			p := atomic.LoadInt64(&peak)
+			if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+				break
+			}
+		}
+
+		<-unblock
+		w.Write([]byte(`{"login":"bot"}`))
+	}))
+	defer s.Close()
+
+	l := NewLimiter(LimiterOptions{MaxInFlight: 2})
+	c := NewContextClient(
+		func() []byte { return []byte("token") },
+		ClientOptions{BaseURL: s.URL + "/api", Limiter: l},
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetBot(context.Background()); err != nil {
+				t.Errorf("get bot: %v", err)
+			}
+		}()
+	}
+
+	waitFor(t, func() bool {
+		return atomic.LoadInt64(&current) == 2 && l.InFlight() == 2 && l.Waiting() == 3
+	})
+
+	if v := testutil.ToFloat64(l.waitingGauge); v != 3 {
+		t.Errorf("expected 3 waiting requests in metrics, got %v", v)
+	}
+
+	close(unblock)
+	wg.Wait()
+
+	if peak := atomic.LoadInt64(&peak); peak != 2 {
+		t.Errorf("expected at most 2 requests in flight, got %d", peak)
+	}
+	if l.InFlight() != 0 || l.Waiting() != 0 {
+		t.Errorf("expected the limiter is idle, got %d in flight and %d waiting", l.InFlight(), l.Waiting())
+	}
+}
+
+func TestLimiterRespectsContext(t *testing.T) {
+	l := NewLimiter(LimiterOptions{MaxInFlight: 1})
+
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if l.Waiting() != 0 {
+		t.Errorf("expected no waiting requests, got %d", l.Waiting())
+	}
+}
+
+func TestLimiterQPS(t *testing.T) {
+	l := NewLimiter(LimiterOptions{QPS: 20, Burst: 1})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := l.acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+
+	// The first token is in the bucket, and the next two take 50ms each.
+	if d := time.Since(start); d < 90*time.Millisecond {
+		t.Errorf("expected the requests are throttled, took %v", d)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the condition")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,199 @@
+package giteeclient
+
+import (
+	"context"
+	"net/http"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"github.com/antihax/optional"
+)
+
+const (
+	OrgRoleAdmin  = "admin"
+	OrgRoleMember = "member"
+)
+
+// OrgMember is a member of organization.
+type OrgMember struct {
+	ID      int32  `json:"id"`
+	Login   string `json:"login"`
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+
+	// Role is OrgRoleAdmin or OrgRoleMember.
+	Role string `json:"member_role"`
+}
+
+// EnterpriseRole is the role of enterprise member. Ident is the identity of
+// the role, such as "admin", "member" and "outsourced".
+type EnterpriseRole struct {
+	ID    int32  `json:"id"`
+	Name  string `json:"name"`
+	Ident string `json:"ident"`
+}
+
+// EnterpriseMember is a member of enterprise.
+type EnterpriseMember struct {
+	Active     bool           `json:"active"`
+	Remark     string         `json:"remark"`
+	Outsourced bool           `json:"outsourced"`
+	Role       EnterpriseRole `json:"role"`
+	User       sdk.UserBasic  `json:"user"`
+}
+
+// ListOrgMembers lists the members whose role is role. All the members
+// are listed if role is empty, and the admins are listed first.
+func (c *client) ListOrgMembers(ctx context.Context, org, role string) ([]OrgMember, error) {
+	ctx = withMethod(ctx, "ListOrgMembers")
+
+	// sdk decodes the members without their roles, so list the members of
+	// each role separately to know the roles.
+	roles := []string{role}
+	if role == "" || role == "all" {
+		roles = []string{OrgRoleAdmin, OrgRoleMember}
+	}
+
+	var r []OrgMember
+	for _, role := range roles {
+		opt := sdk.GetV5OrgsOrgMembersOpts{Role: optional.NewString(role)}
+
+		err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+			opt.Page = optional.NewInt32(page)
+			opt.PerPage = optional.NewInt32(perPage)
+			v, resp, err := c.ac.OrganizationsApi.GetV5OrgsOrgMembers(ctx, org, &opt)
+			if err != nil {
+				return 0, formatErr(err, resp, "list org members")
+			}
+
+			for _, u := range v {
+				r = append(r, OrgMember{
+					ID:      u.Id,
+					Login:   u.Login,
+					Name:    u.Name,
+					HTMLURL: u.HtmlUrl,
+					Role:    role,
+				})
+			}
+			return len(v), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// SetOrgMember adds the user to the organization, or changes the role of the
+// user if it is a member already.
+func (c *client) SetOrgMember(ctx context.Context, org, login, role string) error {
+	ctx = withMethod(ctx, "SetOrgMember")
+	return c.request(
+		ctx, http.MethodPut, apiPath("orgs", org, "memberships", login),
+		map[string]string{"role": role}, nil, "set org member",
+	)
+}
+
+func (c *client) RemoveOrgMember(ctx context.Context, org, login string) error {
+	ctx = withMethod(ctx, "RemoveOrgMember")
+	return c.request(
+		ctx, http.MethodDelete, apiPath("orgs", org, "memberships", login),
+		nil, nil, "remove org member",
+	)
+}
+
+// ListEnterpriseMembers lists all the members of the enterprise, see
+// https://gitee.com/api/v5/swagger#/getV5EnterprisesEnterpriseMembers
+func (c *client) ListEnterpriseMembers(ctx context.Context, enterprise string) ([]EnterpriseMember, error) {
+	ctx = withMethod(ctx, "ListEnterpriseMembers")
+
+	opt := sdk.GetV5EnterprisesEnterpriseMembersOpts{}
+
+	var r []EnterpriseMember
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		v, resp, err := c.ac.EnterprisesApi.GetV5EnterprisesEnterpriseMembers(ctx, enterprise, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "list enterprise members")
+		}
+
+		for i := range v {
+			r = append(r, toEnterpriseMember(&v[i]))
+		}
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func toEnterpriseMember(v *sdk.EnterpriseMember) EnterpriseMember {
+	m := EnterpriseMember{
+		Active:     v.Active,
+		Remark:     v.Remark,
+		Outsourced: v.Outsourced,
+	}
+
+	if v.Role != nil {
+		m.Role = EnterpriseRole{ID: v.Role.Id, Name: v.Role.Name, Ident: v.Role.Ident}
+	}
+	if v.User != nil {
+		m.User = *v.User
+	}
+	return m
+}
+
+// ListUserOrgs lists the public organizations of the user. The organizations
+// are the groups of Gitee, and their members are listed by ListOrgMembers.
+func (c *client) ListUserOrgs(ctx context.Context, login string) ([]sdk.Group, error) {
+	ctx = withMethod(ctx, "ListUserOrgs")
+
+	opt := sdk.GetV5UsersUsernameOrgsOpts{}
+
+	var r []sdk.Group
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		v, resp, err := c.ac.OrganizationsApi.GetV5UsersUsernameOrgs(ctx, login, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "list user orgs")
+		}
+
+		r = append(r, v...)
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// ListBotOrgs lists all the organizations the bot belongs to, including the
+// ones in which its membership is private.
+func (c *client) ListBotOrgs(ctx context.Context) ([]sdk.Group, error) {
+	ctx = withMethod(ctx, "ListBotOrgs")
+
+	opt := sdk.GetV5UserOrgsOpts{}
+
+	var r []sdk.Group
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		v, resp, err := c.ac.OrganizationsApi.GetV5UserOrgs(ctx, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "list bot orgs")
+		}
+
+		r = append(r, v...)
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,126 @@
+package giteeclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "gitee_client"
+	unknownMethod    = "unknown"
+	statusClassError = "error"
+)
+
+type methodKey struct{}
+
+// withMethod records the name of the client method which sends the requests.
+// The outermost method is kept if it calls the other methods.
+func withMethod(ctx context.Context, name string) context.Context {
+	if _, ok := ctx.Value(methodKey{}).(string); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, methodKey{}, name)
+}
+
+// MethodFromContext returns the name of the client method which sends the request
+// with ctx. It returns "unknown" if the request is not sent by a method of client.
+func MethodFromContext(ctx context.Context) string {
+	if v, ok := ctx.Value(methodKey{}).(string); ok {
+		return v
+	}
+	return unknownMethod
+}
+
+// Metrics holds the prometheus collectors of the requests sent to Gitee.
+// It implements prometheus.Collector, so the plugin can register it on its own registry.
+type Metrics struct {
+	requests           *prometheus.CounterVec
+	duration           *prometheus.HistogramVec
+	rateLimitRemaining prometheus.Gauge
+}
+
+// NewMetrics creates the collectors. It should be set to ClientOptions.Metrics
+// to instrument a client.
+func NewMetrics() *Metrics {
+	labels := []string{"method", "status_class"}
+
+	return &Metrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "requests_total",
+				Help:      "The number of requests sent to Gitee.",
+			},
+			labels,
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: metricsNamespace,
+				Name:      "request_duration_seconds",
+				Help:      "The latency of requests sent to Gitee.",
+				Buckets:   prometheus.DefBuckets,
+			},
+			labels,
+		),
+		rateLimitRemaining: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "rate_limit_remaining",
+				Help:      "The remaining requests allowed by the rate limit of Gitee.",
+			},
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+	m.rateLimitRemaining.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+	m.rateLimitRemaining.Collect(ch)
+}
+
+func (m *Metrics) observe(method string, resp *http.Response, err error, d time.Duration) {
+	class := statusClassError
+	if err == nil {
+		class = statusClass(resp.StatusCode)
+
+		v := resp.Header.Get(headerRateLimitRemaining)
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			m.rateLimitRemaining.Set(n)
+		}
+	}
+
+	m.requests.WithLabelValues(method, class).Inc()
+	m.duration.WithLabelValues(method, class).Observe(d.Seconds())
+}
+
+func statusClass(code int) string {
+	return strconv.Itoa(code/100) + "xx"
+}
+
+// metricsTransport reports the metrics of every request.
+type metricsTransport struct {
+	metrics *Metrics
+	base    http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	t.metrics.observe(MethodFromContext(req.Context()), resp, err, time.Since(start))
+
+	return resp, err
+}
@@ -0,0 +1,83 @@
+package giteeclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimitRemaining, "4999")
+
+		if r.URL.Path == "/api/v5/user" {
+			w.Write([]byte(`{"login":"bot"}`))
+			return
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not Found"}`))
+	}))
+	defer s.Close()
+
+	m := NewMetrics()
+
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatalf("register metrics: %v", err)
+	}
+
+	c := NewContextClient(
+		func() []byte { return []byte("token") },
+		ClientOptions{BaseURL: s.URL + "/api", Metrics: m},
+	)
+
+	if _, err := c.GetBot(context.Background()); err != nil {
+		t.Fatalf("get bot: %v", err)
+	}
+
+	if _, err := c.GetGiteePullRequest(context.Background(), "org", "repo", 1); !IsNotFound(err) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+
+	testCases := []struct {
+		method   string
+		class    string
+		expected float64
+	}{
+		{method: "GetBot", class: "2xx", expected: 1},
+		{method: "GetGiteePullRequest", class: "4xx", expected: 1},
+		{method: "GetGiteePullRequest", class: "2xx", expected: 0},
+	}
+
+	for _, tc := range testCases {
+		v := testutil.ToFloat64(m.requests.WithLabelValues(tc.method, tc.class))
+		if v != tc.expected {
+			t.Errorf("%s %s: expected %v requests, got %v", tc.method, tc.class, tc.expected, v)
+		}
+	}
+
+	if v := testutil.ToFloat64(m.rateLimitRemaining); v != 4999 {
+		t.Errorf("expected remaining rate limit 4999, got %v", v)
+	}
+
+	if n := testutil.CollectAndCount(m, "gitee_client_request_duration_seconds"); n != 2 {
+		t.Errorf("expected 2 histograms, got %d", n)
+	}
+}
+
+func TestWithMethodKeepsOutermost(t *testing.T) {
+	ctx := withMethod(withMethod(context.Background(), "IsCollaborator"), "ListCollaborators")
+
+	if v := MethodFromContext(ctx); v != "IsCollaborator" {
+		t.Errorf("expected IsCollaborator, got %s", v)
+	}
+
+	if v := MethodFromContext(context.Background()); v != unknownMethod {
+		t.Errorf("expected %s, got %s", unknownMethod, v)
+	}
+}
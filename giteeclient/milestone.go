@@ -0,0 +1,113 @@
+package giteeclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"github.com/antihax/optional"
+)
+
+// ListMilestones lists the milestones of the repository. state is one of open,
+// closed and all, and only the open milestones are listed if it is empty.
+func (c *client) ListMilestones(ctx context.Context, org, repo, state string) ([]sdk.Milestone, error) {
+	ctx = withMethod(ctx, "ListMilestones")
+
+	opt := sdk.GetV5ReposOwnerRepoMilestonesOpts{}
+	if state != "" {
+		opt.State = optional.NewString(state)
+	}
+
+	var r []sdk.Milestone
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		ms, resp, err := c.ac.MilestonesApi.GetV5ReposOwnerRepoMilestones(ctx, org, repo, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "list milestones")
+		}
+
+		r = append(r, ms...)
+		return len(ms), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *client) GetMilestone(ctx context.Context, org, repo string, number int32) (sdk.Milestone, error) {
+	ctx = withMethod(ctx, "GetMilestone")
+	m, r, err := c.ac.MilestonesApi.GetV5ReposOwnerRepoMilestonesNumber(ctx, org, repo, number, nil)
+	return m, formatErr(err, r, "get milestone")
+}
+
+// CreateMilestone creates a milestone. The title and due_on of param are required.
+func (c *client) CreateMilestone(ctx context.Context, org, repo string, param sdk.MilestonePostParam) (sdk.Milestone, error) {
+	ctx = withMethod(ctx, "CreateMilestone")
+	m, r, err := c.ac.MilestonesApi.PostV5ReposOwnerRepoMilestones(ctx, org, repo, param)
+	return m, formatErr(err, r, "create milestone")
+}
+
+// UpdateMilestone updates a milestone. The title and due_on of param are required
+// by Gitee even if they are not changed.
+func (c *client) UpdateMilestone(ctx context.Context, org, repo string, number int32, param sdk.MilestonePatchParam) (sdk.Milestone, error) {
+	ctx = withMethod(ctx, "UpdateMilestone")
+	m, r, err := c.ac.MilestonesApi.PatchV5ReposOwnerRepoMilestonesNumber(ctx, org, repo, number, param)
+	return m, formatErr(err, r, "update milestone")
+}
+
+func (c *client) CloseMilestone(ctx context.Context, org, repo string, number int32) error {
+	ctx = withMethod(ctx, "CloseMilestone")
+	m, err := c.GetMilestone(ctx, org, repo, number)
+	if err != nil {
+		return err
+	}
+
+	param := sdk.MilestonePatchParam{
+		Title:       m.Title,
+		State:       StatusClosed,
+		Description: m.Description,
+		DueOn:       m.DueOn,
+	}
+	_, err = c.UpdateMilestone(ctx, org, repo, number, param)
+	return err
+}
+
+func (c *client) SetIssueMilestone(ctx context.Context, org, repo, number string, milestone int32) error {
+	ctx = withMethod(ctx, "SetIssueMilestone")
+	opt := sdk.IssueUpdateParam{Repo: repo, Milestone: milestone}
+	_, r, err := c.ac.IssuesApi.PatchV5ReposOwnerIssuesNumber(ctx, org, number, opt)
+	return formatErr(err, r, "set milestone of issue")
+}
+
+// ClearIssueMilestone removes the issue from its milestone. The request is sent
+// without sdk, because sdk omits the milestone whose value is 0.
+func (c *client) ClearIssueMilestone(ctx context.Context, org, repo, number string) error {
+	ctx = withMethod(ctx, "ClearIssueMilestone")
+	body := map[string]interface{}{"repo": repo, "milestone": 0}
+	return c.request(
+		ctx, http.MethodPatch, apiPath("repos", org, "issues", number),
+		body, nil, "clear milestone of issue",
+	)
+}
+
+func (c *client) SetPRMilestone(ctx context.Context, org, repo string, number, milestone int32) error {
+	ctx = withMethod(ctx, "SetPRMilestone")
+	opt := sdk.PullRequestUpdateParam{MilestoneNumber: milestone}
+	_, r, err := c.ac.PullRequestsApi.PatchV5ReposOwnerRepoPullsNumber(ctx, org, repo, number, opt)
+	return formatErr(err, r, "set milestone of pr")
+}
+
+// ClearPRMilestone removes the pull request from its milestone. The request is
+// sent without sdk for the same reason as ClearIssueMilestone.
+func (c *client) ClearPRMilestone(ctx context.Context, org, repo string, number int32) error {
+	ctx = withMethod(ctx, "ClearPRMilestone")
+	body := map[string]interface{}{"milestone_number": 0}
+	return c.request(
+		ctx, http.MethodPatch, apiPath("repos", org, repo, "pulls", strconv.Itoa(int(number))),
+		body, nil, "clear milestone of pr",
+	)
+}
@@ -0,0 +1,59 @@
+package giteeclient
+
+import (
+	"context"
+	"errors"
+)
+
+const (
+	// DefaultPerPage is the page size used when it is not set.
+	DefaultPerPage = 100
+
+	// MaxPerPage is the maximum page size allowed by Gitee.
+	MaxPerPage = 100
+)
+
+// ErrStopPagination can be returned by the item handler to stop the
+// pagination early. The iteration will return nil in that case.
+var ErrStopPagination = errors.New("stop pagination")
+
+// PageFetcher fetches the items of a page which starts from 1 and hands them
+// over one by one. It returns the number of items in the page.
+type PageFetcher func(ctx context.Context, page, perPage int32) (int, error)
+
+// Paginate calls fetch page by page until a page is empty, fetch fails or
+// the ctx is done. A page which is not full does not stop it, since Gitee
+// may return fewer items than perPage before the last page.
+// perPage is normalized to the range Gitee accepts.
+// It returns nil if fetch stops the pagination by ErrStopPagination.
+func Paginate(ctx context.Context, perPage int32, fetch PageFetcher) error {
+	perPage = normalizePerPage(perPage)
+
+	for page := int32(1); ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		n, err := fetch(ctx, page, perPage)
+		if err != nil {
+			if errors.Is(err, ErrStopPagination) {
+				return nil
+			}
+			return err
+		}
+
+		if n == 0 {
+			return nil
+		}
+	}
+}
+
+func normalizePerPage(n int32) int32 {
+	if n <= 0 {
+		return DefaultPerPage
+	}
+	if n > MaxPerPage {
+		return MaxPerPage
+	}
+	return n
+}
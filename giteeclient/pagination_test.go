@@ -0,0 +1,125 @@
+package giteeclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	testCases := []struct {
+		description string
+		perPage     int32
+		total       int
+		stopAt      int
+		fetchErr    error
+		expectPages []int32
+		expectSize  int32
+		expectErr   error
+	}{
+		{
+			description: "go on after the page which is not full",
+			perPage:     10,
+			total:       25,
+			expectPages: []int32{1, 2, 3, 4},
+			expectSize:  10,
+		},
+		{
+			description: "stop at the empty page",
+			perPage:     10,
+			total:       20,
+			expectPages: []int32{1, 2, 3},
+			expectSize:  10,
+		},
+		{
+			description: "stop early by the handler",
+			perPage:     10,
+			total:       100,
+			stopAt:      15,
+			expectPages: []int32{1, 2},
+			expectSize:  10,
+		},
+		{
+			description: "return the error of fetch",
+			perPage:     10,
+			total:       100,
+			fetchErr:    errFetch,
+			expectPages: []int32{1},
+			expectSize:  10,
+			expectErr:   errFetch,
+		},
+		{
+			description: "use the default page size",
+			total:       5,
+			expectPages: []int32{1, 2},
+			expectSize:  DefaultPerPage,
+		},
+		{
+			description: "cap the page size",
+			perPage:     MaxPerPage + 1,
+			total:       5,
+			expectPages: []int32{1, 2},
+			expectSize:  MaxPerPage,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var pages []int32
+			handled := 0
+
+			err := Paginate(context.Background(), tc.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+				pages = append(pages, page)
+				if perPage != tc.expectSize {
+					t.Errorf("expected page size %d, got %d", tc.expectSize, perPage)
+				}
+
+				if tc.fetchErr != nil {
+					return 0, tc.fetchErr
+				}
+
+				n := 0
+				for i := int(page-1) * int(perPage); i < tc.total && n < int(perPage); i++ {
+					if handled++; handled == tc.stopAt {
+						return 0, ErrStopPagination
+					}
+					n++
+				}
+				return n, nil
+			})
+
+			if !errors.Is(err, tc.expectErr) {
+				t.Errorf("expected error %v, got %v", tc.expectErr, err)
+			}
+
+			if len(pages) != len(tc.expectPages) {
+				t.Fatalf("expected pages %v, got %v", tc.expectPages, pages)
+			}
+			for i := range pages {
+				if pages[i] != tc.expectPages[i] {
+					t.Fatalf("expected pages %v, got %v", tc.expectPages, pages)
+				}
+			}
+		})
+	}
+}
+
+func TestPaginateCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	err := Paginate(ctx, 10, func(ctx context.Context, page, perPage int32) (int, error) {
+		called = true
+		return 0, nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled, got %v", err)
+	}
+	if called {
+		t.Error("fetch should not be called after the ctx is done")
+	}
+}
@@ -0,0 +1,110 @@
+package giteeclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PRReviewer is a reviewer or tester of pull request.
+type PRReviewer struct {
+	ID    int32  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+
+	// Accept is true if the reviewer has approved the review, or the tester
+	// has passed the test.
+	Accept bool `json:"accept"`
+
+	CodeOwner bool `json:"code_owner"`
+}
+
+// PRReviewState is the state of the review and test of pull request.
+// sdk.PullRequest doesn't have it, because sdk.UserBasic misses the field of accept.
+type PRReviewState struct {
+	Assignees []PRReviewer `json:"assignees"`
+	Testers   []PRReviewer `json:"testers"`
+
+	// AssigneesNumber is the number of approvals the review requires.
+	AssigneesNumber int32 `json:"assignees_number"`
+
+	// TestersNumber is the number of passes the test requires.
+	TestersNumber int32 `json:"testers_number"`
+}
+
+// ReviewApproved reports whether the review has got enough approvals.
+func (s PRReviewState) ReviewApproved() bool {
+	return countAccepted(s.Assignees) >= int(s.AssigneesNumber)
+}
+
+// TestPassed reports whether the test has got enough passes.
+func (s PRReviewState) TestPassed() bool {
+	return countAccepted(s.Testers) >= int(s.TestersNumber)
+}
+
+func countAccepted(v []PRReviewer) int {
+	n := 0
+	for i := range v {
+		if v[i].Accept {
+			n++
+		}
+	}
+	return n
+}
+
+func prPath(org, repo string, number int32, segments ...string) string {
+	return apiPath(append([]string{"repos", org, repo, "pulls", strconv.Itoa(int(number))}, segments...)...)
+}
+
+func (c *client) GetPRReviewState(ctx context.Context, org, repo string, number int32) (PRReviewState, error) {
+	ctx = withMethod(ctx, "GetPRReviewState")
+	var v PRReviewState
+	err := c.request(ctx, http.MethodGet, prPath(org, repo, number), nil, &v, "get review state of pr")
+	return v, err
+}
+
+func (c *client) AssignPRTesters(ctx context.Context, org, repo string, number int32, logins []string) error {
+	ctx = withMethod(ctx, "AssignPRTesters")
+	body := map[string]string{"testers": strings.Join(logins, ",")}
+	return c.request(ctx, http.MethodPost, prPath(org, repo, number, "testers"), body, nil, "assign tester to pr")
+}
+
+func (c *client) UnassignPRTesters(ctx context.Context, org, repo string, number int32, logins []string) error {
+	ctx = withMethod(ctx, "UnassignPRTesters")
+	p := prPath(org, repo, number, "testers") + "?testers=" + url.QueryEscape(strings.Join(logins, ","))
+	return c.request(ctx, http.MethodDelete, p, nil, nil, "unassign tester from pr")
+}
+
+// ApprovePRReview approves the review as the bot, which must be a reviewer of
+// the pr unless it is forced. Only the administrators can force it.
+func (c *client) ApprovePRReview(ctx context.Context, org, repo string, number int32, force bool) error {
+	ctx = withMethod(ctx, "ApprovePRReview")
+	body := map[string]bool{"force": force}
+	return c.request(ctx, http.MethodPost, prPath(org, repo, number, "review"), body, nil, "approve review of pr")
+}
+
+// PassPRTest passes the test as the bot, which must be a tester of the pr
+// unless it is forced. Only the administrators can force it.
+func (c *client) PassPRTest(ctx context.Context, org, repo string, number int32, force bool) error {
+	ctx = withMethod(ctx, "PassPRTest")
+	body := map[string]bool{"force": force}
+	return c.request(ctx, http.MethodPost, prPath(org, repo, number, "test"), body, nil, "pass test of pr")
+}
+
+// ResetPRReview withdraws the approval of the bot, or all the approvals if
+// resetAll is true which is only allowed to the administrators.
+func (c *client) ResetPRReview(ctx context.Context, org, repo string, number int32, resetAll bool) error {
+	ctx = withMethod(ctx, "ResetPRReview")
+	body := map[string]bool{"reset_all": resetAll}
+	return c.request(ctx, http.MethodPatch, prPath(org, repo, number, "assignees"), body, nil, "reset review of pr")
+}
+
+// ResetPRTest withdraws the pass of the bot, or all the passes if resetAll is
+// true which is only allowed to the administrators.
+func (c *client) ResetPRTest(ctx context.Context, org, repo string, number int32, resetAll bool) error {
+	ctx = withMethod(ctx, "ResetPRTest")
+	body := map[string]bool{"reset_all": resetAll}
+	return c.request(ctx, http.MethodPatch, prPath(org, repo, number, "testers"), body, nil, "reset test of pr")
+}
@@ -0,0 +1,243 @@
+// Package recorder implements an http.RoundTripper which records the
+// traffic with Gitee to a cassette file and replays it later. It can
+// be set as giteeclient.ClientOptions.Transport to turn a real scenario
+// into a deterministic test.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Mode is the mode of Recorder.
+type Mode int
+
+const (
+	// ModeRecord sends the requests to Gitee and records the interactions.
+	ModeRecord Mode = iota
+
+	// ModeReplay answers the requests with the recorded interactions
+	// and never sends them to Gitee.
+	ModeReplay
+)
+
+const paramAccessToken = "access_token"
+
+// the headers which are never recorded since they may contain the secrets.
+var sensitiveHeaders = []string{"Set-Cookie", "Authorization"}
+
+// NoInteractionError is returned when replaying a request which has no
+// recorded answer, or whose recorded answers have all been used.
+type NoInteractionError struct {
+	Request Request
+}
+
+func (e *NoInteractionError) Error() string {
+	r := e.Request
+	return fmt.Sprintf(
+		"no recorded interaction for request: %s %s?%s, body: %q",
+		r.Method, r.Path, r.Query, r.Body,
+	)
+}
+
+// Recorder records or replays the interactions with Gitee.
+type Recorder struct {
+	mode     Mode
+	path     string
+	base     http.RoundTripper
+	mut      sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// New creates a Recorder for the cassette file.
+// In ModeRecord, the requests are sent by base, or http.DefaultTransport
+// if it is nil, and Stop must be called to save the cassette.
+// In ModeReplay, the cassette is loaded from path.
+func New(path string, mode Mode, base http.RoundTripper) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path, base: base}
+
+	switch mode {
+	case ModeRecord:
+		r.cassette = new(Cassette)
+		if r.base == nil {
+			r.base = http.DefaultTransport
+		}
+
+	case ModeReplay:
+		c, err := LoadCassette(path)
+		if err != nil {
+			return nil, err
+		}
+		r.cassette = c
+		r.used = make([]bool, len(c.Interactions))
+
+	default:
+		return nil, fmt.Errorf("unknown mode: %d", mode)
+	}
+
+	return r, nil
+}
+
+// Stop saves the cassette in ModeRecord and does nothing in ModeReplay.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	return r.cassette.Save(r.path)
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	v, out, err := newRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, v)
+	}
+	return r.record(out, v)
+}
+
+func (r *Recorder) record(req *http.Request, v Request) (*http.Response, error) {
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	h := resp.Header.Clone()
+	for _, k := range sensitiveHeaders {
+		h.Del(k)
+	}
+
+	r.mut.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: v,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     h,
+			Body:       string(body),
+		},
+	})
+	r.mut.Unlock()
+
+	return resp, nil
+}
+
+// replay answers the request with the first unused interaction matching it,
+// so that the same request can get different answers in the recorded order.
+func (r *Recorder) replay(req *http.Request, v Request) (*http.Response, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for i := range r.cassette.Interactions {
+		item := &r.cassette.Interactions[i]
+		if r.used[i] || item.Request != v {
+			continue
+		}
+
+		r.used[i] = true
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", item.Response.StatusCode, http.StatusText(item.Response.StatusCode)),
+			StatusCode:    item.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        item.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader([]byte(item.Response.Body))),
+			ContentLength: int64(len(item.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, &NoInteractionError{Request: v}
+}
+
+// newRequest returns the Request of req without the token, and the request to
+// send. req is never modified as http.RoundTripper requires. Its body is read
+// from GetBody if it is set, otherwise req is cloned to carry the body read.
+func newRequest(req *http.Request) (Request, *http.Request, error) {
+	q := req.URL.Query()
+	q.Del(paramAccessToken)
+
+	v := Request{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  q.Encode(),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return v, req, nil
+	}
+
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return v, nil, err
+		}
+
+		body, err := readAll(rc)
+		if err != nil {
+			return v, nil, err
+		}
+
+		v.Body = normalizeBody(body)
+		return v, req, nil
+	}
+
+	body, err := readAll(req.Body)
+	if err != nil {
+		return v, nil, err
+	}
+
+	out := req.Clone(req.Context())
+	out.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	v.Body = normalizeBody(body)
+	return v, out, nil
+}
+
+func readAll(rc io.ReadCloser) ([]byte, error) {
+	defer rc.Close()
+
+	return ioutil.ReadAll(rc)
+}
+
+// normalizeBody removes the token from the json or form body and returns it
+// in a canonical form, so that the order of fields does not matter.
+func normalizeBody(body []byte) string {
+	var m map[string]interface{}
+	if err := json.Unmarshal(body, &m); err == nil {
+		delete(m, paramAccessToken)
+
+		if b, err := json.Marshal(m); err == nil {
+			return string(b)
+		}
+	}
+
+	if form, err := url.ParseQuery(string(body)); err == nil && form.Get(paramAccessToken) != "" {
+		form.Del(paramAccessToken)
+		return form.Encode()
+	}
+
+	return string(body)
+}
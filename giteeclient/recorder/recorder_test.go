@@ -0,0 +1,161 @@
+package recorder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+
+	"github.com/opensourceways/community-robot-lib/giteeclient"
+	"github.com/opensourceways/community-robot-lib/giteeclient/giteetest"
+)
+
+const token = "secret-token"
+
+func newClient(baseURL string, r *Recorder) giteeclient.ContextClient {
+	return giteeclient.NewContextClient(
+		func() []byte { return []byte(token) },
+		giteeclient.ClientOptions{BaseURL: baseURL, Transport: r},
+	)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "cassette.yaml")
+	ctx := context.Background()
+
+	s := giteetest.NewServer()
+	number := s.Fake.AddPullRequest("org", "repo", sdk.PullRequest{Title: "fix"})
+	baseURL := s.BaseURL()
+
+	rec, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newClient(baseURL, rec)
+	if err := c.CreatePRComment(ctx, "org", "repo", number, "/lgtm"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetGiteePullRequest(ctx, "org", "repo", 100); !giteeclient.IsNotFound(err) {
+		t.Fatalf("expected not found, got: %v", err)
+	}
+	if err := rec.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), token) {
+		t.Fatalf("the token is recorded:\n%s", b)
+	}
+
+	rep, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = newClient(baseURL, rep)
+	if err := c.CreatePRComment(ctx, "org", "repo", number, "/lgtm"); err != nil {
+		t.Errorf("failed to replay, err: %v", err)
+	}
+	if _, err := c.GetGiteePullRequest(ctx, "org", "repo", 100); !giteeclient.IsNotFound(err) {
+		t.Errorf("expected not found when replaying, got: %v", err)
+	}
+
+	// The interaction has been used up.
+	err = c.CreatePRComment(ctx, "org", "repo", number, "/lgtm")
+
+	var e *NoInteractionError
+	if !errors.As(err, &e) {
+		t.Fatalf("expected NoInteractionError, got: %v", err)
+	}
+	if e.Request.Method != "POST" || !strings.HasSuffix(e.Request.Path, "/pulls/1/comments") {
+		t.Errorf("unexpected request in the error: %+v", e.Request)
+	}
+}
+
+func TestNormalizeBody(t *testing.T) {
+	testCases := []struct {
+		description string
+		body        string
+		expected    string
+	}{
+		{
+			description: "token in json is removed and fields are sorted",
+			body:        `{"body":"hi","access_token":"x","a":1}`,
+			expected:    `{"a":1,"body":"hi"}`,
+		},
+		{
+			description: "token in form is removed",
+			body:        "body=hi&access_token=x",
+			expected:    "body=hi",
+		},
+		{
+			description: "other body is kept",
+			body:        "plain text",
+			expected:    "plain text",
+		},
+	}
+
+	for _, tc := range testCases {
+		if v := normalizeBody([]byte(tc.body)); v != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.description, tc.expected, v)
+		}
+	}
+}
+
+func TestRecordKeepsRequest(t *testing.T) {
+	var got []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		got = append(got, string(b))
+	}))
+	defer s.Close()
+
+	rec, err := New(filepath.Join(t.TempDir(), "cassette.yaml"), ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The body of the first request has no GetBody.
+	bodies := []io.Reader{
+		ioutil.NopCloser(strings.NewReader(`{"body":"a"}`)),
+		strings.NewReader(`{"body":"b"}`),
+	}
+	for _, b := range bodies {
+		req, err := http.NewRequest(http.MethodPost, s.URL+"/comments", b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, getBody := req.Body, req.GetBody
+
+		resp, err := rec.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+
+		if req.Body != body || (getBody == nil) != (req.GetBody == nil) {
+			t.Errorf("the request is modified")
+		}
+	}
+
+	if want := []string{`{"body":"a"}`, `{"body":"b"}`}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("expected the bodies %v sent, got %v", want, got)
+	}
+	for i, item := range rec.cassette.Interactions {
+		if item.Request.Body != got[i] {
+			t.Errorf("expected the body %s recorded, got %s", got[i], item.Request.Body)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package giteeclient
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
+
+// ReleaseAsset is an attachment of release, including the source archives
+// generated by Gitee.
+type ReleaseAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// Release is a release of repository.
+type Release struct {
+	ID              int32          `json:"id"`
+	TagName         string         `json:"tag_name"`
+	TargetCommitish string         `json:"target_commitish"`
+	Prerelease      bool           `json:"prerelease"`
+	Name            string         `json:"name"`
+	Body            string         `json:"body"`
+	Author          *sdk.UserBasic `json:"author,omitempty"`
+	CreatedAt       string         `json:"created_at"`
+	Assets          []ReleaseAsset `json:"assets"`
+}
+
+// ReleaseParam is the parameter to create or update a release. It is sent
+// without sdk which omits Prerelease of false, so a prerelease can't be
+// changed to a release by sdk.
+type ReleaseParam struct {
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Prerelease bool   `json:"prerelease"`
+
+	// TargetCommitish is the branch or commit to create the tag if the tag
+	// does not exist. It is only used when creating a release.
+	TargetCommitish string `json:"target_commitish,omitempty"`
+}
+
+// TagCommit is the commit which a tag points to.
+type TagCommit struct {
+	Sha  string `json:"sha"`
+	Date string `json:"date"`
+}
+
+// Tag is a tag of repository.
+type Tag struct {
+	Name    string    `json:"name"`
+	Message string    `json:"message"`
+	Commit  TagCommit `json:"commit"`
+}
+
+func releasePath(org, repo string, segments ...string) string {
+	return apiPath(append([]string{"repos", org, repo, "releases"}, segments...)...)
+}
+
+func (c *client) ListReleases(ctx context.Context, org, repo string) ([]Release, error) {
+	ctx = withMethod(ctx, "ListReleases")
+
+	var r []Release
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		var v []Release
+		p := pagePath(releasePath(org, repo), page, perPage)
+		if err := c.request(ctx, http.MethodGet, p, nil, &v, "list releases"); err != nil {
+			return 0, err
+		}
+
+		r = append(r, v...)
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *client) GetRelease(ctx context.Context, org, repo string, id int32) (Release, error) {
+	ctx = withMethod(ctx, "GetRelease")
+	var v Release
+	err := c.request(ctx, http.MethodGet, releasePath(org, repo, strconv.Itoa(int(id))), nil, &v, "get release")
+	return v, err
+}
+
+func (c *client) GetReleaseByTag(ctx context.Context, org, repo, tag string) (Release, error) {
+	ctx = withMethod(ctx, "GetReleaseByTag")
+	var v Release
+	err := c.request(ctx, http.MethodGet, releasePath(org, repo, "tags", tag), nil, &v, "get release by tag")
+	return v, err
+}
+
+func (c *client) CreateRelease(ctx context.Context, org, repo string, param ReleaseParam) (Release, error) {
+	ctx = withMethod(ctx, "CreateRelease")
+	var v Release
+	err := c.request(ctx, http.MethodPost, releasePath(org, repo), param, &v, "create release")
+	return v, err
+}
+
+func (c *client) UpdateRelease(ctx context.Context, org, repo string, id int32, param ReleaseParam) (Release, error) {
+	ctx = withMethod(ctx, "UpdateRelease")
+	param.TargetCommitish = ""
+
+	var v Release
+	err := c.request(
+		ctx, http.MethodPatch, releasePath(org, repo, strconv.Itoa(int(id))),
+		param, &v, "update release",
+	)
+	return v, err
+}
+
+func (c *client) DeleteRelease(ctx context.Context, org, repo string, id int32) error {
+	ctx = withMethod(ctx, "DeleteRelease")
+	return c.request(
+		ctx, http.MethodDelete, releasePath(org, repo, strconv.Itoa(int(id))),
+		nil, nil, "delete release",
+	)
+}
+
+func (c *client) ListTags(ctx context.Context, org, repo string) ([]Tag, error) {
+	ctx = withMethod(ctx, "ListTags")
+
+	var r []Tag
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		var v []Tag
+		p := pagePath(apiPath("repos", org, repo, "tags"), page, perPage)
+		if err := c.request(ctx, http.MethodGet, p, nil, &v, "list tags"); err != nil {
+			return 0, err
+		}
+
+		r = append(r, v...)
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CreateTag creates a lightweight tag on ref, which is a commit sha or a branch.
+// The branch is resolved to the commit by GetRef first, so that the tag points
+// to the commit seen by the caller even if the branch moves meanwhile.
+func (c *client) CreateTag(ctx context.Context, org, repo, tag, ref string) (Tag, error) {
+	ctx = withMethod(ctx, "CreateTag")
+
+	sha := ref
+	if !isCommitSHA(ref) {
+		v, err := c.GetRef(ctx, org, repo, ref)
+		if err != nil {
+			return Tag{}, err
+		}
+		sha = v
+	}
+
+	var v Tag
+	err := c.request(
+		ctx, http.MethodPost, apiPath("repos", org, repo, "tags"),
+		map[string]string{"refs": sha, "tag_name": tag}, &v, "create tag",
+	)
+	return v, err
+}
+
+func isCommitSHA(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
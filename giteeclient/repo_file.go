@@ -0,0 +1,214 @@
+package giteeclient
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
+
+const (
+	FileActionCreate = "create"
+	FileActionUpdate = "update"
+	FileActionDelete = "delete"
+	FileActionMove   = "move"
+)
+
+// FileChange is a change of file committed by CommitFiles.
+type FileChange struct {
+	// Action is one of FileActionCreate, FileActionUpdate, FileActionDelete and FileActionMove.
+	Action string
+
+	Path string
+
+	// PreviousPath is the path of the file to move.
+	PreviousPath string
+
+	// Content is the new content of the file. It is ignored when deleting the file,
+	// and the file keeps its content when it is moved with an empty Content.
+	Content string
+}
+
+type fileAction struct {
+	Action       string  `json:"action"`
+	Path         string  `json:"path"`
+	PreviousPath string  `json:"previous_path,omitempty"`
+	Content      *string `json:"content,omitempty"`
+	Encoding     string  `json:"encoding,omitempty"`
+}
+
+type commitFilesParam struct {
+	Branch  string       `json:"branch"`
+	Message string       `json:"message"`
+	Actions []fileAction `json:"actions"`
+}
+
+func contentPath(org, repo, path string) string {
+	return apiPath(append([]string{"repos", org, repo, "contents"}, strings.Split(path, "/")...)...)
+}
+
+// errFileChanged is the error of a conflict which IsConflict reports, when a
+// file has been changed since it was read.
+var errFileChanged = errors.New("the file has been changed")
+
+// UpdateFile updates the file whose sha is the one read before. It fails with
+// an error which IsConflict reports if the file has been changed since then.
+func (c *client) UpdateFile(ctx context.Context, org, repo, branch, path, content, sha, commitMsg string) (sdk.CommitContent, error) {
+	ctx = withMethod(ctx, "UpdateFile")
+	body := map[string]string{
+		"content": base64.StdEncoding.EncodeToString([]byte(content)),
+		"sha":     sha,
+		"message": commitMsg,
+		"branch":  branch,
+	}
+
+	var v sdk.CommitContent
+	err := c.request(ctx, http.MethodPut, contentPath(org, repo, path), body, &v, "update file")
+	if err != nil {
+		return v, c.checkFileChanged(ctx, err, org, repo, branch, path, sha)
+	}
+	return v, nil
+}
+
+// DeleteFile deletes the file whose sha is the one read before. It fails with
+// an error which IsConflict reports if the file has been changed since then.
+func (c *client) DeleteFile(ctx context.Context, org, repo, branch, path, sha, commitMsg string) (sdk.CommitContent, error) {
+	ctx = withMethod(ctx, "DeleteFile")
+	q := url.Values{}
+	q.Set("sha", sha)
+	q.Set("message", commitMsg)
+	q.Set("branch", branch)
+
+	var v sdk.CommitContent
+	err := c.request(
+		ctx, http.MethodDelete, contentPath(org, repo, path)+"?"+q.Encode(),
+		nil, &v, "delete file",
+	)
+	if err != nil {
+		return v, c.checkFileChanged(ctx, err, org, repo, branch, path, sha)
+	}
+	return v, nil
+}
+
+// CommitFiles commits the changes to the branch in a single commit, so that
+// either all or none of them are applied. It fails with an error which
+// IsConflict reports if a file to create exists or a file to change does not.
+func (c *client) CommitFiles(ctx context.Context, org, repo, branch, commitMsg string, changes []FileChange) (sdk.RepoCommit, error) {
+	ctx = withMethod(ctx, "CommitFiles")
+	param := commitFilesParam{
+		Branch:  branch,
+		Message: commitMsg,
+		Actions: make([]fileAction, len(changes)),
+	}
+	for i, item := range changes {
+		a := fileAction{
+			Action:       item.Action,
+			Path:         item.Path,
+			PreviousPath: item.PreviousPath,
+		}
+		if hasContent(item) {
+			v := base64.StdEncoding.EncodeToString([]byte(item.Content))
+			a.Content = &v
+			a.Encoding = "base64"
+		}
+		param.Actions[i] = a
+	}
+
+	var v sdk.RepoCommit
+	err := c.request(
+		ctx, http.MethodPost, apiPath("repos", org, repo, "commits"),
+		param, &v, "commit files",
+	)
+	if err != nil {
+		return v, c.checkFilesChanged(ctx, err, org, repo, branch, changes)
+	}
+	return v, nil
+}
+
+func hasContent(v FileChange) bool {
+	switch v.Action {
+	case FileActionDelete:
+		return false
+	case FileActionMove:
+		return v.Content != ""
+	}
+	return true
+}
+
+// isRejected reports whether Gitee rejected the request as invalid, which is
+// how it responds to a change conflicting with the files instead of 409.
+func isRejected(err error) bool {
+	code := StatusCode(err)
+	return code == http.StatusBadRequest || code == http.StatusUnprocessableEntity
+}
+
+// asFileChanged turns err into a conflict, and keeps the response of Gitee.
+func asFileChanged(err error) error {
+	var e *Error
+	if !errors.As(err, &e) {
+		return err
+	}
+
+	return &Error{
+		Op:         e.Op,
+		StatusCode: http.StatusConflict,
+		Header:     e.Header,
+		Body:       e.Body,
+		err:        errFileChanged,
+	}
+}
+
+// checkFileChanged turns err into a conflict if the file on the branch is not
+// the one whose sha is sha any more. The message of Gitee is not documented,
+// so the file is read again to tell.
+func (c *client) checkFileChanged(ctx context.Context, err error, org, repo, branch, path, sha string) error {
+	if sha == "" || !isRejected(err) {
+		return err
+	}
+
+	v, e := c.GetPathContent(ctx, org, repo, path, branch)
+	if (e == nil && v.Sha != sha) || IsNotFound(e) {
+		return asFileChanged(err)
+	}
+	return err
+}
+
+// checkFilesChanged turns err into a conflict if a file to create exists or a
+// file to change does not, which is read again as checkFileChanged does.
+func (c *client) checkFilesChanged(ctx context.Context, err error, org, repo, branch string, changes []FileChange) error {
+	if !isRejected(err) {
+		return err
+	}
+
+	// differs reports whether the existence of the file is not the expected one.
+	differs := func(path string, exist bool) bool {
+		_, e := c.GetPathContent(ctx, org, repo, path, branch)
+		if e != nil && !IsNotFound(e) {
+			return false
+		}
+		return (e == nil) != exist
+	}
+
+	for _, item := range changes {
+		changed := false
+
+		switch item.Action {
+		case FileActionCreate:
+			changed = differs(item.Path, false)
+		case FileActionUpdate, FileActionDelete:
+			changed = differs(item.Path, true)
+		case FileActionMove:
+			changed = differs(item.PreviousPath, true) || differs(item.Path, false)
+		}
+
+		if changed {
+			return asFileChanged(err)
+		}
+	}
+
+	return err
+}
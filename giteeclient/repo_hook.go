@@ -0,0 +1,115 @@
+package giteeclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// HookEncryptionPassword means Gitee sends the password as is in the
+	// header X-Gitee-Token of every delivery.
+	HookEncryptionPassword = 0
+
+	// HookEncryptionSignKey means the password is used as the key to sign
+	// the deliveries, which is what ValidateWebhook expects.
+	HookEncryptionSignKey = 1
+)
+
+// HookEvents selects the events which trigger a repository webhook.
+type HookEvents struct {
+	PushEvents          bool `json:"push_events"`
+	TagPushEvents       bool `json:"tag_push_events"`
+	IssuesEvents        bool `json:"issues_events"`
+	NoteEvents          bool `json:"note_events"`
+	MergeRequestsEvents bool `json:"merge_requests_events"`
+}
+
+// Hook is a webhook of repository.
+type Hook struct {
+	HookEvents
+
+	ID             int32  `json:"id"`
+	URL            string `json:"url"`
+	Password       string `json:"password"`
+	EncryptionType int    `json:"encryption_type"`
+	ProjectID      int32  `json:"project_id"`
+	Result         string `json:"result"`
+	ResultCode     int32  `json:"result_code"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// HookParam is the parameter to create or update a repository webhook.
+// The events not selected are disabled explicitly, so it is sent without
+// sdk which omits the fields of false.
+type HookParam struct {
+	HookEvents
+
+	URL string `json:"url"`
+
+	// Password is sent as is or used as the signing key according to EncryptionType.
+	Password string `json:"password,omitempty"`
+
+	// EncryptionType is HookEncryptionPassword or HookEncryptionSignKey.
+	EncryptionType int `json:"encryption_type"`
+}
+
+func hookPath(org, repo string, segments ...string) string {
+	return apiPath(append([]string{"repos", org, repo, "hooks"}, segments...)...)
+}
+
+func (c *client) ListRepoHooks(ctx context.Context, org, repo string) ([]Hook, error) {
+	ctx = withMethod(ctx, "ListRepoHooks")
+
+	var r []Hook
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		var hooks []Hook
+		p := fmt.Sprintf("%s?page=%d&per_page=%d", hookPath(org, repo), page, perPage)
+		if err := c.request(ctx, http.MethodGet, p, nil, &hooks, "list repo hooks"); err != nil {
+			return 0, err
+		}
+
+		r = append(r, hooks...)
+		return len(hooks), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (c *client) CreateRepoHook(ctx context.Context, org, repo string, param HookParam) (Hook, error) {
+	ctx = withMethod(ctx, "CreateRepoHook")
+	var v Hook
+	err := c.request(ctx, http.MethodPost, hookPath(org, repo), param, &v, "create repo hook")
+	return v, err
+}
+
+func (c *client) UpdateRepoHook(ctx context.Context, org, repo string, id int32, param HookParam) (Hook, error) {
+	ctx = withMethod(ctx, "UpdateRepoHook")
+	var v Hook
+	err := c.request(
+		ctx, http.MethodPatch, hookPath(org, repo, strconv.Itoa(int(id))),
+		param, &v, "update repo hook",
+	)
+	return v, err
+}
+
+// TestRepoHook asks Gitee to deliver a push event to the webhook.
+func (c *client) TestRepoHook(ctx context.Context, org, repo string, id int32) error {
+	ctx = withMethod(ctx, "TestRepoHook")
+	return c.request(
+		ctx, http.MethodPost, hookPath(org, repo, strconv.Itoa(int(id)), "tests"),
+		nil, nil, "test repo hook",
+	)
+}
+
+func (c *client) DeleteRepoHook(ctx context.Context, org, repo string, id int32) error {
+	ctx = withMethod(ctx, "DeleteRepoHook")
+	return c.request(
+		ctx, http.MethodDelete, hookPath(org, repo, strconv.Itoa(int(id))),
+		nil, nil, "delete repo hook",
+	)
+}
@@ -0,0 +1,85 @@
+package giteeclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// request sends a request to the API of Gitee without sdk. It is used when sdk
+// can't express the request, such as sending a field of zero value which sdk omits.
+// path is relative to /v5, and body and out are encoded as json if they are not nil.
+func (c *client) request(ctx context.Context, method, path string, body, out interface{}, doWhat string) error {
+	var data []byte
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return formatErr(err, nil, doWhat)
+		}
+		data = b
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.conf.BasePath+"/v5"+path, bytes.NewReader(data))
+	if err != nil {
+		return formatErr(err, nil, doWhat)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.conf.UserAgent != "" {
+		req.Header.Set("User-Agent", c.conf.UserAgent)
+	}
+
+	resp, err := c.conf.HTTPClient.Do(req)
+	if err != nil {
+		return formatErr(err, nil, doWhat)
+	}
+	defer resp.Body.Close()
+
+	v, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return formatErr(err, resp, doWhat)
+	}
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return &Error{
+			Op:         doWhat,
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       v,
+			err:        errors.New(resp.Status),
+		}
+	}
+
+	if out == nil || len(v) == 0 {
+		return nil
+	}
+
+	return formatErr(json.Unmarshal(v, out), resp, doWhat)
+}
+
+// apiPath joins the segments to a path of the API, and every segment is escaped.
+func apiPath(segments ...string) string {
+	v := make([]string, len(segments))
+	for i, s := range segments {
+		v[i] = url.PathEscape(s)
+	}
+	return "/" + strings.Join(v, "/")
+}
+
+// pagePath appends the query of pagination to path which may have a query.
+func pagePath(path string, page, perPage int32) string {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%spage=%d&per_page=%d", path, sep, page, perPage)
+}
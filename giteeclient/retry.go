@@ -0,0 +1,232 @@
+package giteeclient
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	headerRetryAfter     = "Retry-After"
+	headerRateLimitReset = "X-RateLimit-Reset"
+)
+
+// RetryPolicy is the policy to retry the failed requests.
+// The request will be retried if it failed because of network error,
+// rate limit or server error.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries. Zero means no retry.
+	MaxRetries int
+
+	// MinBackoff is the backoff before the first retry.
+	// It doubles after each retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the upper bound of backoff. It also bounds the waiting time
+	// required by Retry-After or rate limit headers. The request will not be
+	// retried if the required waiting time exceeds it.
+	MaxBackoff time.Duration
+
+	// RetryMutating specifies whether to retry the requests which are not
+	// idempotent, such as creating a comment. Only GET and HEAD requests
+	// are retried by default.
+	RetryMutating bool
+}
+
+// DefaultRetryPolicy returns the policy used by NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: 500 * time.Millisecond,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+func (p *RetryPolicy) canRetry(req *http.Request) bool {
+	if p.MaxRetries <= 0 {
+		return false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	}
+	return p.RetryMutating
+}
+
+// backoff returns the waiting time with jitter before the nth retry which starts from 0.
+func (p *RetryPolicy) backoff(n int) time.Duration {
+	d := p.MinBackoff
+	for i := 0; i < n && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if half := int64(d / 2); half > 0 {
+		return time.Duration(half + rand.Int63n(half))
+	}
+	return d
+}
+
+type retryTransport struct {
+	policy RetryPolicy
+	base   http.RoundTripper
+}
+
+func newRetryTransport(p RetryPolicy, base http.RoundTripper) http.RoundTripper {
+	if p.MaxBackoff < p.MinBackoff {
+		p.MaxBackoff = p.MinBackoff
+	}
+
+	return &retryTransport{policy: p, base: base}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.policy.canRetry(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	for n := 0; ; n++ {
+		if n > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			// Don't modify the original request.
+			r := *req
+			r.Body = body
+			req = &r
+		}
+
+		resp, err := t.base.RoundTrip(req)
+
+		if n >= t.policy.MaxRetries || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait, ok := waitingTime(resp)
+		if !ok {
+			wait = t.policy.backoff(n)
+		}
+		if wait > t.policy.MaxBackoff {
+			return resp, err
+		}
+
+		if resp != nil {
+			drainBody(resp.Body)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// timeoutTransport limits the time of a try, which lasts until the response
+// body is closed. It sits below retryTransport, so that a try which times out
+// can be retried and the waiting time between the tries is not limited.
+type timeoutTransport struct {
+	timeout time.Duration
+	base    http.RoundTripper
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose cancels the context of request when the body is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusTooManyRequests:
+		return true
+	case code == http.StatusForbidden:
+		return resp.Header.Get(headerRateLimitRemaining) == "0"
+	case code == http.StatusNotImplemented:
+		return false
+	case code >= http.StatusInternalServerError:
+		return true
+	}
+	return false
+}
+
+// waitingTime returns the waiting time required by the Retry-After
+// or rate limit headers of the response.
+func waitingTime(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if v := resp.Header.Get(headerRetryAfter); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return time.Duration(n) * time.Second, true
+		}
+
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(time.Until(t)), true
+		}
+	}
+
+	if resp.Header.Get(headerRateLimitRemaining) == "0" {
+		if n, err := strconv.ParseInt(resp.Header.Get(headerRateLimitReset), 10, 64); err == nil {
+			return nonNegative(time.Until(time.Unix(n, 0))), true
+		}
+	}
+
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// drainBody reads the rest of body, so that the connection can be reused.
+func drainBody(body io.ReadCloser) {
+	if body == nil {
+		return
+	}
+
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(body, 4096))
+	body.Close()
+}
@@ -0,0 +1,163 @@
+package giteeclient
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries: 2,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 10 * time.Millisecond,
+	}
+
+	testCases := []struct {
+		description   string
+		method        string
+		retryMutating bool
+		codes         []int
+		header        http.Header
+		expectedCode  int
+		expectedCalls int
+	}{
+		{
+			description:   "retry GET on server error",
+			method:        http.MethodGet,
+			codes:         []int{http.StatusBadGateway, http.StatusOK},
+			expectedCode:  http.StatusOK,
+			expectedCalls: 2,
+		},
+		{
+			description:   "give up after max retries",
+			method:        http.MethodGet,
+			codes:         []int{http.StatusServiceUnavailable},
+			expectedCode:  http.StatusServiceUnavailable,
+			expectedCalls: 3,
+		},
+		{
+			description:   "don't retry on client error",
+			method:        http.MethodGet,
+			codes:         []int{http.StatusNotFound},
+			expectedCode:  http.StatusNotFound,
+			expectedCalls: 1,
+		},
+		{
+			description:   "don't retry POST by default",
+			method:        http.MethodPost,
+			codes:         []int{http.StatusBadGateway, http.StatusCreated},
+			expectedCode:  http.StatusBadGateway,
+			expectedCalls: 1,
+		},
+		{
+			description:   "retry POST if opted in",
+			method:        http.MethodPost,
+			retryMutating: true,
+			codes:         []int{http.StatusTooManyRequests, http.StatusCreated},
+			expectedCode:  http.StatusCreated,
+			expectedCalls: 2,
+		},
+		{
+			description:   "don't retry if Retry-After exceeds the max backoff",
+			method:        http.MethodGet,
+			codes:         []int{http.StatusTooManyRequests, http.StatusOK},
+			header:        http.Header{headerRetryAfter: []string{"60"}},
+			expectedCode:  http.StatusTooManyRequests,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			calls := 0
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					if b, err := ioutil.ReadAll(r.Body); err != nil || string(b) != "body" {
+						t.Errorf("expected the body to be resent, got %q", b)
+					}
+				}
+
+				code := tc.codes[len(tc.codes)-1]
+				if calls < len(tc.codes) {
+					code = tc.codes[calls]
+				}
+				calls++
+
+				for k, v := range tc.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(code)
+			}))
+			defer s.Close()
+
+			p := policy
+			p.RetryMutating = tc.retryMutating
+			cli := http.Client{Transport: newRetryTransport(p, http.DefaultTransport)}
+
+			req, err := http.NewRequest(tc.method, s.URL, strings.NewReader("body"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := cli.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.expectedCode {
+				t.Errorf("expected status code %d, got %d", tc.expectedCode, resp.StatusCode)
+			}
+			if calls != tc.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tc.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestTimeoutPerTry(t *testing.T) {
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		switch calls {
+		case 1:
+			// The first try times out.
+			<-r.Context().Done()
+		case 2:
+			// The waiting time exceeds the timeout, but it is not counted.
+			w.Header().Set(headerRetryAfter, "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer s.Close()
+
+	opts := ClientOptions{
+		Timeout: 100 * time.Millisecond,
+		RetryPolicy: RetryPolicy{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 2 * time.Second,
+		},
+	}
+	conf := opts.newConfiguration(func() []byte { return []byte("token") })
+
+	resp, err := conf.HTTPClient.Get(s.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if b, err := ioutil.ReadAll(resp.Body); err != nil || string(b) != "ok" {
+		t.Errorf("expected the body ok, got %q, %v", b, err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
@@ -0,0 +1,97 @@
+package giteeclient
+
+import (
+	"context"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+	"github.com/antihax/optional"
+)
+
+// SearchRepos searches the repositories whose name or description contains q.
+func (c *client) SearchRepos(ctx context.Context, q string, opts SearchRepoOpt) ([]sdk.Project, error) {
+	ctx = withMethod(ctx, "SearchRepos")
+
+	opt := sdk.GetV5SearchRepositoriesOpts{Fork: optional.NewBool(opts.Fork)}
+	setStr(&opt.Owner, opts.Owner)
+	setStr(&opt.Language, opts.Language)
+	setStr(&opt.Sort, opts.Sort)
+	setStr(&opt.Order, opts.Order)
+
+	var r []sdk.Project
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		v, resp, err := c.ac.SearchApi.GetV5SearchRepositories(ctx, q, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "search repos")
+		}
+
+		r = append(r, v...)
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// SearchIssues searches the issues whose title or body contains q.
+func (c *client) SearchIssues(ctx context.Context, q string, opts SearchIssueOpt) ([]sdk.Issue, error) {
+	ctx = withMethod(ctx, "SearchIssues")
+
+	opt := sdk.GetV5SearchIssuesOpts{}
+	setStr(&opt.Repo, opts.Repo)
+	setStr(&opt.Language, opts.Language)
+	setStr(&opt.Label, opts.Label)
+	setStr(&opt.State, opts.State)
+	setStr(&opt.Author, opts.Author)
+	setStr(&opt.Assignee, opts.Assignee)
+	setStr(&opt.Sort, opts.Sort)
+	setStr(&opt.Order, opts.Order)
+
+	var r []sdk.Issue
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		v, resp, err := c.ac.SearchApi.GetV5SearchIssues(ctx, q, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "search issues")
+		}
+
+		r = append(r, v...)
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// SearchUsers searches the users whose login or name contains q.
+func (c *client) SearchUsers(ctx context.Context, q string, opts SearchUserOpt) ([]sdk.User, error) {
+	ctx = withMethod(ctx, "SearchUsers")
+
+	opt := sdk.GetV5SearchUsersOpts{}
+	setStr(&opt.Sort, opts.Sort)
+	setStr(&opt.Order, opts.Order)
+
+	var r []sdk.User
+	err := Paginate(ctx, c.perPage, func(ctx context.Context, page, perPage int32) (int, error) {
+		opt.Page = optional.NewInt32(page)
+		opt.PerPage = optional.NewInt32(perPage)
+		v, resp, err := c.ac.SearchApi.GetV5SearchUsers(ctx, q, &opt)
+		if err != nil {
+			return 0, formatErr(err, resp, "search users")
+		}
+
+		r = append(r, v...)
+		return len(v), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,58 @@
+package giteeclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+var errEmptyToken = errors.New("the gitee token is empty, please check the secret")
+
+// tokenSource implements oauth2.TokenSource. It asks getToken for the token
+// on every request, so that a token rotated in the secret takes effect
+// without restarting the process.
+type tokenSource struct {
+	getToken func() []byte
+
+	mut       sync.Mutex
+	token     string
+	changedAt time.Time
+}
+
+func newTokenSource(getToken func() []byte) *tokenSource {
+	return &tokenSource{getToken: getToken}
+}
+
+func (ts *tokenSource) Token() (*oauth2.Token, error) {
+	v := ts.getToken()
+	if len(v) == 0 {
+		return nil, errEmptyToken
+	}
+
+	token := string(v)
+
+	ts.mut.Lock()
+	if token != ts.token {
+		// don't log the first loading
+		if ts.token != "" {
+			logrus.WithField("last-changed-at", ts.changedAt).Info("the gitee token has changed")
+		}
+
+		ts.token = token
+		ts.changedAt = time.Now()
+	}
+	ts.mut.Unlock()
+
+	return &oauth2.Token{AccessToken: token}, nil
+}
+
+// lastChangedAt returns the time when the token was changed at last.
+func (ts *tokenSource) lastChangedAt() time.Time {
+	ts.mut.Lock()
+	defer ts.mut.Unlock()
+
+	return ts.changedAt
+}
@@ -0,0 +1,261 @@
+package giteeclient
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// TokenRoundRobin picks the tokens in turn.
+	TokenRoundRobin = "round-robin"
+
+	// TokenLeastUsed picks the token with the fewest requests in flight,
+	// and then the fewest requests sent.
+	TokenLeastUsed = "least-used"
+
+	// DefaultTokenCooldown is how long a token is out of rotation by default.
+	DefaultTokenCooldown = 10 * time.Minute
+)
+
+// DefaultPrimaryMethods returns the client methods which use the primary token
+// by default. They create or change the objects whose author is the owner of
+// token, such as comments, or they read the owner of token.
+func DefaultPrimaryMethods() []string {
+	return []string{
+		"CreatePullRequest", "MergePR",
+		"CreatePRComment", "UpdatePRComment", "DeletePRComment",
+		"CreateIssue", "CreateIssueComment", "UpdateIssueComment",
+		"ApprovePRReview", "PassPRTest",
+		"CreateRelease", "CreateTag",
+		"CreateFile", "UpdateFile", "DeleteFile", "CommitFiles",
+		"GetBot", "ListBotEmails", "ListBotOrgs",
+		"ForkRepo", "GetBotFork", "WaitForFork",
+	}
+}
+
+// TokenPoolOptions holds the options of the token pool.
+type TokenPoolOptions struct {
+	// Strategy is TokenRoundRobin or TokenLeastUsed.
+	// TokenRoundRobin will be used if it is empty.
+	Strategy string
+
+	// Cooldown is how long a token is out of rotation after it was revoked or
+	// rate limited. The waiting time required by the rate limit headers will be
+	// used instead if there is. DefaultTokenCooldown will be used if it is zero.
+	Cooldown time.Duration
+
+	// PrimaryMethods are the client methods which always use the primary token.
+	// DefaultPrimaryMethods will be used if it is nil. Besides, the comments are
+	// always written with the primary token even if the method is unknown.
+	PrimaryMethods []string
+}
+
+// NewTokenPoolClient creates a client which picks one of the tokens stored in
+// paths for every request, such as:
+//
+//	NewTokenPoolClient(secretAgent.GetSecret, paths, TokenPoolOptions{}, opts)
+//
+// The first path is the primary token, which is used for the writes sensitive to
+// the author. All the tokens should belong to the accounts with the same permissions.
+func NewTokenPoolClient(getSecret func(path string) []byte, paths []string, poolOpts TokenPoolOptions, opts ClientOptions) (ContextClient, error) {
+	p, err := newTokenPool(getSecret, paths, poolOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	auth := func(base http.RoundTripper) http.RoundTripper {
+		return &tokenPoolTransport{pool: p, base: base}
+	}
+	return newClient(opts.newConfigurationWithAuth(auth, true), opts), nil
+}
+
+type pooledToken struct {
+	path      string
+	inFlight  int
+	used      int64
+	downUntil time.Time
+}
+
+type tokenPool struct {
+	getSecret func(string) []byte
+	strategy  string
+	cooldown  time.Duration
+	primary   sets.String
+
+	mut    sync.Mutex
+	tokens []*pooledToken
+	next   int
+}
+
+func newTokenPool(getSecret func(string) []byte, paths []string, opts TokenPoolOptions) (*tokenPool, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no token path")
+	}
+
+	switch opts.Strategy {
+	case "":
+		opts.Strategy = TokenRoundRobin
+	case TokenRoundRobin, TokenLeastUsed:
+	default:
+		return nil, errors.New("unknown strategy: " + opts.Strategy)
+	}
+
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = DefaultTokenCooldown
+	}
+
+	if opts.PrimaryMethods == nil {
+		opts.PrimaryMethods = DefaultPrimaryMethods()
+	}
+
+	p := &tokenPool{
+		getSecret: getSecret,
+		strategy:  opts.Strategy,
+		cooldown:  opts.Cooldown,
+		primary:   sets.NewString(opts.PrimaryMethods...),
+	}
+	for _, v := range paths {
+		p.tokens = append(p.tokens, &pooledToken{path: v})
+	}
+
+	return p, nil
+}
+
+// needPrimary reports whether the request must be sent with the primary token.
+func (p *tokenPool) needPrimary(req *http.Request) bool {
+	if m := MethodFromContext(req.Context()); m != unknownMethod {
+		return p.primary.Has(m)
+	}
+
+	// The request is not sent by a known method, so decide by the path.
+	path := req.URL.Path
+	if strings.HasSuffix(path, "/user") || strings.HasSuffix(path, "/emails") {
+		return true
+	}
+	return req.Method != http.MethodGet && strings.Contains(path+"/", "/comments/")
+}
+
+// pick returns the token to send req. The tokens out of rotation or empty are
+// skipped, and the one which recovers first is used if all of them are skipped.
+func (p *tokenPool) pick(req *http.Request) (*pooledToken, string, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.needPrimary(req) {
+		return p.use(p.tokens[0])
+	}
+
+	now := time.Now()
+	var best, earliest *pooledToken
+
+	n := len(p.tokens)
+	for i := 0; i < n; i++ {
+		t := p.tokens[(p.next+i)%n]
+
+		if len(p.getSecret(t.path)) == 0 {
+			continue
+		}
+
+		if now.Before(t.downUntil) {
+			if earliest == nil || t.downUntil.Before(earliest.downUntil) {
+				earliest = t
+			}
+			continue
+		}
+
+		if best == nil {
+			best = t
+			if p.strategy == TokenRoundRobin {
+				p.next = (p.next + i + 1) % n
+				break
+			}
+			continue
+		}
+
+		if t.inFlight < best.inFlight || (t.inFlight == best.inFlight && t.used < best.used) {
+			best = t
+		}
+	}
+
+	if best == nil {
+		best = earliest
+	}
+	if best == nil {
+		return nil, "", errEmptyToken
+	}
+	return p.use(best)
+}
+
+func (p *tokenPool) use(t *pooledToken) (*pooledToken, string, error) {
+	token := p.getSecret(t.path)
+	if len(token) == 0 {
+		return nil, "", errEmptyToken
+	}
+
+	t.inFlight++
+	t.used++
+	return t, string(token), nil
+}
+
+// done takes the token out of rotation if it was revoked or rate limited.
+func (p *tokenPool) done(t *pooledToken, resp *http.Response, err error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	t.inFlight--
+
+	if err != nil {
+		return
+	}
+
+	var reason string
+	d := p.cooldown
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusUnauthorized:
+		reason = "revoked"
+	case code == http.StatusTooManyRequests,
+		code == http.StatusForbidden && resp.Header.Get(headerRateLimitRemaining) == "0":
+		reason = "rate limited"
+		if v, ok := waitingTime(resp); ok {
+			d = v
+		}
+	default:
+		return
+	}
+
+	t.downUntil = time.Now().Add(d)
+
+	logrus.WithFields(logrus.Fields{
+		"token-path": t.path,
+		"until":      t.downUntil,
+	}).Warnf("the gitee token is %s, take it out of rotation", reason)
+}
+
+// tokenPoolTransport authorizes the request with a token picked from the pool.
+type tokenPoolTransport struct {
+	pool *tokenPool
+	base http.RoundTripper
+}
+
+func (t *tokenPoolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	pt, token, err := t.pool.pick(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r := req.Clone(req.Context())
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := t.base.RoundTrip(r)
+
+	t.pool.done(pt, resp, err)
+
+	return resp, err
+}
@@ -0,0 +1,184 @@
+package giteeclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type tokenRecorder struct {
+	mut     sync.Mutex
+	tokens  []string
+	limited string
+}
+
+func (tr *tokenRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	tr.mut.Lock()
+	tr.tokens = append(tr.tokens, token)
+	limited := tr.limited
+	tr.mut.Unlock()
+
+	if token == limited {
+		w.Header().Set(headerRetryAfter, "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message":"Too Many Requests"}`))
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		w.WriteHeader(http.StatusCreated)
+	}
+	w.Write([]byte(`{}`))
+}
+
+func (tr *tokenRecorder) take() []string {
+	tr.mut.Lock()
+	defer tr.mut.Unlock()
+
+	v := tr.tokens
+	tr.tokens = nil
+	return v
+}
+
+func newTokenPoolTestClient(t *testing.T, url string, secrets map[string]string, poolOpts TokenPoolOptions) ContextClient {
+	c, err := NewTokenPoolClient(
+		func(path string) []byte { return []byte(secrets[path]) },
+		[]string{"/primary", "/second", "/third"},
+		poolOpts,
+		ClientOptions{BaseURL: url + "/api"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestTokenPool(t *testing.T) {
+	tr := &tokenRecorder{}
+	s := httptest.NewServer(tr)
+	defer s.Close()
+
+	secrets := map[string]string{"/primary": "a", "/second": "b", "/third": "c"}
+	c := newTokenPoolTestClient(t, s.URL, secrets, TokenPoolOptions{})
+	ctx := context.Background()
+
+	for i := 0; i < 4; i++ {
+		if _, err := c.GetRepo(ctx, "org", "repo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v := strings.Join(tr.take(), ","); v != "a,b,c,a" {
+		t.Errorf("expected round robin, got %s", v)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.CreatePRComment(ctx, "org", "repo", 1, "comment"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v := strings.Join(tr.take(), ","); v != "a,a" {
+		t.Errorf("expected the comments use the primary token, got %s", v)
+	}
+
+	// b is rate limited and then taken out of rotation.
+	tr.mut.Lock()
+	tr.limited = "b"
+	tr.mut.Unlock()
+
+	if _, err := c.GetRepo(ctx, "org", "repo"); StatusCode(err) != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %v", err)
+	}
+	tr.take()
+
+	for i := 0; i < 4; i++ {
+		if _, err := c.GetRepo(ctx, "org", "repo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v := strings.Join(tr.take(), ","); v != "c,a,c,a" {
+		t.Errorf("expected b is skipped, got %s", v)
+	}
+
+	// A token whose secret is empty is skipped.
+	secrets["/third"] = ""
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetRepo(ctx, "org", "repo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v := strings.Join(tr.take(), ","); v != "a,a" {
+		t.Errorf("expected only the primary token is used, got %s", v)
+	}
+}
+
+func TestTokenPoolLeastUsed(t *testing.T) {
+	secrets := map[string]string{"/primary": "a", "/second": "b", "/third": "c"}
+	p, err := newTokenPool(
+		func(path string) []byte { return []byte(secrets[path]) },
+		[]string{"/primary", "/second", "/third"},
+		TokenPoolOptions{Strategy: TokenLeastUsed},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v5/repos/org/repo", nil)
+
+	p.tokens[0].inFlight = 2
+	p.tokens[1].inFlight = 1
+	p.tokens[1].used = 5
+	p.tokens[2].inFlight = 1
+	p.tokens[2].used = 3
+
+	if tk, _, _ := p.pick(req); tk.path != "/third" {
+		t.Errorf("expected /third, got %s", tk.path)
+	}
+
+	// All the tokens are out of rotation, and the one recovering first is used.
+	now := time.Now()
+	p.tokens[0].downUntil = now.Add(time.Minute)
+	p.tokens[1].downUntil = now.Add(time.Second)
+	p.tokens[2].downUntil = now.Add(time.Hour)
+
+	if tk, _, _ := p.pick(req); tk.path != "/second" {
+		t.Errorf("expected /second, got %s", tk.path)
+	}
+}
+
+func TestTokenPoolNeedPrimary(t *testing.T) {
+	p, err := newTokenPool(func(string) []byte { return nil }, []string{"/primary"}, TokenPoolOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		method   string
+		name     string
+		path     string
+		expected bool
+	}{
+		{method: http.MethodPost, name: "CreateIssueComment", path: "/api/v5/repos/o/r/issues/I1/comments", expected: true},
+		{method: http.MethodGet, name: "ListIssueComments", path: "/api/v5/repos/o/r/issues/I1/comments", expected: false},
+		{method: http.MethodPost, path: "/api/v5/repos/o/r/pulls/1/comments", expected: true},
+		{method: http.MethodPatch, path: "/api/v5/repos/o/r/pulls/comments/1", expected: true},
+		{method: http.MethodGet, path: "/api/v5/user", expected: true},
+		{method: http.MethodPost, path: "/api/v5/repos/o/r/labels", expected: false},
+	}
+
+	for _, tc := range testCases {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.name != "" {
+			req = req.WithContext(withMethod(req.Context(), tc.name))
+		}
+
+		if v := p.needPrimary(req); v != tc.expected {
+			t.Errorf("%s %s %s: expected %v, got %v", tc.name, tc.method, tc.path, tc.expected, v)
+		}
+	}
+}
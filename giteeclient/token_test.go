@@ -0,0 +1,44 @@
+package giteeclient
+
+import "testing"
+
+func TestTokenSource(t *testing.T) {
+	secret := []byte("token1")
+	ts := newTokenSource(func() []byte { return secret })
+
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "token1" {
+		t.Errorf("expected token1, got %s", token.AccessToken)
+	}
+
+	changedAt := ts.lastChangedAt()
+	if changedAt.IsZero() {
+		t.Errorf("expected the changed time to be recorded")
+	}
+
+	if _, err := ts.Token(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ts.lastChangedAt().Equal(changedAt) {
+		t.Errorf("expected the changed time to be kept when the token is not changed")
+	}
+
+	secret = []byte("token2")
+	if token, err = ts.Token(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "token2" {
+		t.Errorf("expected the rotated token2, got %s", token.AccessToken)
+	}
+	if !ts.lastChangedAt().After(changedAt) {
+		t.Errorf("expected the changed time to be updated")
+	}
+
+	secret = nil
+	if _, err = ts.Token(); err != errEmptyToken {
+		t.Errorf("expected error: %v, got: %v", errEmptyToken, err)
+	}
+}
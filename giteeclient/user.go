@@ -0,0 +1,127 @@
+package giteeclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "gitee.com/openeuler/go-gitee/gitee"
+)
+
+// userCacheSweepSize is the number of cached users beyond which the expired
+// ones are removed when a new one is added.
+const userCacheSweepSize = 1024
+
+// UserEmail is an email of the authenticated user.
+type UserEmail struct {
+	Email string `json:"email"`
+
+	// State is "confirmed" if the email has been verified.
+	State string `json:"state"`
+
+	// Scope is the usages of the email, such as "primary", "committed" and "notified".
+	Scope []string `json:"scope"`
+}
+
+type cachedUser struct {
+	user     sdk.User
+	expireAt time.Time
+}
+
+// userCache caches the users by login for ttl. The logins of Gitee
+// are case-insensitive, so are the keys.
+type userCache struct {
+	ttl time.Duration
+
+	mut   sync.Mutex
+	users map[string]cachedUser
+}
+
+func newUserCache(ttl time.Duration) *userCache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &userCache{ttl: ttl, users: map[string]cachedUser{}}
+}
+
+func (uc *userCache) get(login string) (sdk.User, bool) {
+	k := strings.ToLower(login)
+
+	uc.mut.Lock()
+	defer uc.mut.Unlock()
+
+	v, ok := uc.users[k]
+	if !ok {
+		return sdk.User{}, false
+	}
+
+	if time.Now().After(v.expireAt) {
+		delete(uc.users, k)
+		return sdk.User{}, false
+	}
+	return v.user, true
+}
+
+func (uc *userCache) set(login string, u sdk.User) {
+	now := time.Now()
+
+	uc.mut.Lock()
+	defer uc.mut.Unlock()
+
+	if len(uc.users) >= userCacheSweepSize {
+		for k, v := range uc.users {
+			if now.After(v.expireAt) {
+				delete(uc.users, k)
+			}
+		}
+	}
+
+	uc.users[strings.ToLower(login)] = cachedUser{user: u, expireAt: now.Add(uc.ttl)}
+}
+
+// GetUser returns the public profile of the user. The result is cached if
+// ClientOptions.UserCacheTTL is set, and the errors are never cached.
+func (c *client) GetUser(ctx context.Context, login string) (sdk.User, error) {
+	ctx = withMethod(ctx, "GetUser")
+
+	if c.users != nil {
+		if u, ok := c.users.get(login); ok {
+			return u, nil
+		}
+	}
+
+	u, r, err := c.ac.UsersApi.GetV5UsersUsername(ctx, login, nil)
+	if err != nil {
+		return sdk.User{}, formatErr(err, r, "get user")
+	}
+
+	if c.users != nil {
+		c.users.set(login, u)
+	}
+	return u, nil
+}
+
+// GetUserEmail returns the public email of the user. It is empty if the user
+// does not make the email public, which Gitee allows.
+func (c *client) GetUserEmail(ctx context.Context, login string) (string, error) {
+	ctx = withMethod(ctx, "GetUserEmail")
+
+	u, err := c.GetUser(ctx, login)
+	if err != nil {
+		return "", err
+	}
+	return u.Email, nil
+}
+
+// ListBotEmails lists all the emails of the bot, including the private ones.
+// Gitee only permits a user to read its own emails, and the token requires
+// the emails scope.
+func (c *client) ListBotEmails(ctx context.Context) ([]UserEmail, error) {
+	ctx = withMethod(ctx, "ListBotEmails")
+	var v []UserEmail
+	err := c.request(ctx, http.MethodGet, apiPath("emails"), nil, &v, "list bot emails")
+	return v, err
+}
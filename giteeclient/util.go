@@ -85,6 +85,8 @@ const (
 	EventTypePush  = "Push Hook"
 	EventTypeIssue = "Issue Hook"
 	EventTypePR    = "Merge Request Hook"
+
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
 )
 
 func GetPullRequestAction(e *sdk.PullRequestEvent) string {
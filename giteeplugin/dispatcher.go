@@ -103,11 +103,7 @@ func (d *dispatcher) handlePullRequestEvent(e *sdk.PullRequestEvent, l *logrus.E
 		logFieldAction: e.GetActionDesc(),
 	})
 
-	if err := d.h.pullRequestHandler(e, d.getConfig(), l); err != nil {
-		l.WithError(err).Error()
-	} else {
-		l.Info()
-	}
+	logResult(l, d.h.pullRequestHandler(e, d.getConfig(), l))
 }
 
 func (d *dispatcher) handleIssueEvent(e *sdk.IssueEvent, l *logrus.Entry) {
@@ -118,11 +114,7 @@ func (d *dispatcher) handleIssueEvent(e *sdk.IssueEvent, l *logrus.Entry) {
 		logFieldAction: *e.Action,
 	})
 
-	if err := d.h.issueHandlers(e, d.getConfig(), l); err != nil {
-		l.WithError(err).Error()
-	} else {
-		l.Info()
-	}
+	logResult(l, d.h.issueHandlers(e, d.getConfig(), l))
 }
 
 func (d *dispatcher) handlePushEvent(e *sdk.PushEvent, l *logrus.Entry) {
@@ -137,11 +129,7 @@ func (d *dispatcher) handlePushEvent(e *sdk.PushEvent, l *logrus.Entry) {
 		"head":       e.After,
 	})
 
-	if err := d.h.pushEventHandler(e, d.getConfig(), l); err != nil {
-		l.WithError(err).Error()
-	} else {
-		l.Info()
-	}
+	logResult(l, d.h.pushEventHandler(e, d.getConfig(), l))
 }
 
 func (d *dispatcher) handleNoteEvent(e *sdk.NoteEvent, l *logrus.Entry) {
@@ -153,9 +141,18 @@ func (d *dispatcher) handleNoteEvent(e *sdk.NoteEvent, l *logrus.Entry) {
 		logFieldAction: *e.Action,
 	})
 
-	if err := d.h.noteEventHandler(e, d.getConfig(), l); err != nil {
-		l.WithError(err).Error()
-	} else {
+	logResult(l, d.h.noteEventHandler(e, d.getConfig(), l))
+}
+
+// logResult logs the result of handler. The events skipped because Gitee is
+// unavailable are warned, since they are expected during an outage of Gitee.
+func logResult(l *logrus.Entry, err error) {
+	switch {
+	case err == nil:
 		l.Info()
+	case giteeclient.IsCircuitOpen(err):
+		l.WithError(err).Warn("gitee is unavailable, skip the event")
+	default:
+		l.WithError(err).Error()
 	}
 }
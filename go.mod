@@ -5,8 +5,10 @@ go 1.16
 require (
 	gitee.com/openeuler/go-gitee v0.0.0-20211126062945-e0d9fc01dbaa
 	github.com/antihax/optional v1.0.0
+	github.com/prometheus/client_golang v1.10.0
 	github.com/sirupsen/logrus v1.8.1
 	golang.org/x/oauth2 v0.0.0-20210819190943-2bc19b11175f
+	golang.org/x/time v0.0.0-20210723032227-1f47c861a9ac
 	k8s.io/apimachinery v0.22.1
 	sigs.k8s.io/yaml v1.2.0
 )
@@ -48,9 +48,20 @@ func main() {
 		logrus.WithError(err).Fatal("Error starting secret agent.")
 	}
 
-	c := giteeclient.NewClient(secretAgent.GetTokenGenerator(o.gitee.TokenPath))
+	var c giteeclient.ContextClient = giteeclient.NewContextClient(
+		secretAgent.GetTokenGenerator(o.gitee.TokenPath),
+		giteeclient.ClientOptions{
+			BaseURL:     o.gitee.BaseURL,
+			Timeout:     o.gitee.Timeout,
+			QPS:         o.gitee.QPS,
+			RetryPolicy: giteeclient.DefaultRetryPolicy(),
+		},
+	)
+	if o.gitee.DryRun {
+		c = giteeclient.NewDryRunClient(c)
+	}
 
-	p := newRobot(c)
+	p := newRobot(giteeclient.WrapContextClient(c))
 
 	libplugin.Run(p, o.plugin)
 
@@ -3,13 +3,21 @@ package options
 import (
 	"flag"
 	"fmt"
+	"net/url"
+	"time"
 )
 
+const defaultGiteeBaseURL = "https://gitee.com/api"
+
 // GiteeOptions holds options for interacting with Gitee.
 type GiteeOptions struct {
 	TokenPath     string
 	RepoCacheDir  string
 	CacheRepoOnPV bool
+	BaseURL       string
+	Timeout       time.Duration
+	QPS           float64
+	DryRun        bool
 }
 
 // NewGiteeOptions creates a GiteeOptions with default values.
@@ -33,6 +41,10 @@ func (o *GiteeOptions) addFlags(defaultGiteeTokenPath string, fs *flag.FlagSet)
 	fs.StringVar(&o.TokenPath, "gitee-token-path", defaultGiteeTokenPath, "Path to the file containing the Gitee OAuth secret.")
 	fs.StringVar(&o.RepoCacheDir, "repo-cache-dir", "", "Path to which clone repo.")
 	fs.BoolVar(&o.CacheRepoOnPV, "cache-repo-on-pv", false, "Specify whether to cache repo on persistent volume.")
+	fs.StringVar(&o.BaseURL, "gitee-base-url", defaultGiteeBaseURL, "The address of Gitee API.")
+	fs.DurationVar(&o.Timeout, "gitee-timeout", 0, "The time limit for each try of a request to Gitee. 0 means no timeout.")
+	fs.Float64Var(&o.QPS, "gitee-qps", 0, "The maximum number of requests sent to Gitee per second. 0 means no limit.")
+	fs.BoolVar(&o.DryRun, "gitee-dry-run", false, "Only log the calls to Gitee which change something instead of doing them.")
 }
 
 // Validate validates Gitee options.
@@ -40,5 +52,20 @@ func (o GiteeOptions) Validate() error {
 	if o.CacheRepoOnPV && o.RepoCacheDir == "" {
 		return fmt.Errorf("must set repo-cache-dir if caching repo on persistent volume")
 	}
+
+	if o.BaseURL != "" {
+		if _, err := url.ParseRequestURI(o.BaseURL); err != nil {
+			return fmt.Errorf("invalid gitee-base-url: %v", err)
+		}
+	}
+
+	if o.Timeout < 0 {
+		return fmt.Errorf("gitee-timeout must not be negative")
+	}
+
+	if o.QPS < 0 {
+		return fmt.Errorf("gitee-qps must not be negative")
+	}
+
 	return nil
 }